@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"flag"
 	"fmt"
 	"log"
@@ -9,21 +10,43 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/impression"
 	"github.com/Raschudesny/otus_project/v1/internal/logger"
 	"github.com/Raschudesny/otus_project/v1/internal/server"
 	"github.com/Raschudesny/otus_project/v1/internal/services"
 	"github.com/Raschudesny/otus_project/v1/internal/stats"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/memory"
 	"github.com/Raschudesny/otus_project/v1/internal/storage/sql"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/sqlite"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"go.uber.org/zap"
 )
 
+// Storage is a repository of the rotation service which should be connected before usage.
+type Storage interface {
+	services.Repository
+	Connect(ctx context.Context) error
+	Close() error
+}
+
+// CatalogChangesListener is a storage which notifies about catalog changes made by all service instances.
+type CatalogChangesListener interface {
+	ListenCatalogChanges(ctx context.Context, onChange func(change storage.CatalogChange))
+}
+
 var TerminalSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGABRT, syscall.SIGHUP}
 
 var configPath string
 
+const (
+	writeBehindFlushTimeout = 10 * time.Second
+	debugServerStopTimeout  = 5 * time.Second
+)
+
 func init() {
 	flag.StringVar(&configPath, "config", "./configs/config.yaml", "path to config file")
 }
@@ -54,8 +77,11 @@ func mainImpl() error {
 	ctx, stop := signal.NotifyContext(context.Background(), TerminalSignals...)
 	defer stop()
 
-	zap.L().Info("rotation service storage starting...")
-	dbStorage := sql.NewStorage("pgx", cnf.DB)
+	zap.L().Info("rotation service storage starting...", zap.String("type", cnf.DB.Type))
+	dbStorage, err := newStorage(cnf.DB)
+	if err != nil {
+		return fmt.Errorf("error during storage initialization: %w", err)
+	}
 	if err := dbStorage.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to init db storage: %w", err)
 	}
@@ -79,14 +105,115 @@ func mainImpl() error {
 		if err := publisher.Stop(); err != nil {
 			zap.L().Error("error during stats publisher stopping", zap.Error(err))
 		}
-		zap.L().Info("rotation service stats publisher stopped")
+		zap.L().Info("rotation service stats publisher stopped", zap.Any("metrics", publisher.Metrics()))
 	}()
+	expvar.Publish("stats_publisher", expvar.Func(func() interface{} {
+		return publisher.Metrics()
+	}))
+	var events services.EventsPublisher = publisher
+	if cnf.Publisher.Async.Enabled {
+		// outbox relay publishes events again after the lease, so the relay mustn't wait for the queue
+		// and events which didn't fit into the queue mustn't be published once more from the spill file
+		if cnf.Publisher.Async.Overflow != stats.OverflowDrop {
+			return fmt.Errorf("async stats publisher overflow policy %q can't be used with outbox relay, use %q",
+				cnf.Publisher.Async.Overflow, stats.OverflowDrop)
+		}
+		asyncPublisher, err := stats.NewAsyncPublisher(publisher, cnf.Publisher.Async)
+		if err != nil {
+			return fmt.Errorf("error during async stats publisher initialization: %w", err)
+		}
+		asyncPublisher.Start()
+		// deferred after publisher stop, so queued events are drained before the publisher is stopped
+		defer func() {
+			asyncPublisher.Stop()
+			zap.L().Info("async stats publisher stopped", zap.Any("metrics", asyncPublisher.Metrics()))
+		}()
+		expvar.Publish("stats_async_publisher", expvar.Func(func() interface{} {
+			return asyncPublisher.Metrics()
+		}))
+		events = asyncPublisher
+		zap.L().Info("async stats publishing enabled",
+			zap.Int("queueSize", cnf.Publisher.Async.QueueSize),
+			zap.String("overflow", cnf.Publisher.Async.Overflow))
+	}
+
+	strategy, err := services.NewStrategy(cnf.Rotation)
+	if err != nil {
+		return fmt.Errorf("error during rotation strategy initialization: %w", err)
+	}
+	zap.L().Info("rotation strategy selected", zap.String("strategy", cnf.Rotation.Strategy))
+
+	impressions, err := impression.NewSigner(cnf.Impression)
+	if err != nil {
+		return fmt.Errorf("error during impression signer initialization: %w", err)
+	}
+
+	var repo services.Repository = dbStorage
+	var writeBehind *services.WriteBehindRepository
+	if cnf.WriteBehind.Enabled {
+		writeBehind = services.NewWriteBehindRepository(dbStorage, cnf.WriteBehind)
+		repo = writeBehind
+		zap.L().Info("write-behind stats buffering enabled",
+			zap.Duration("flushInterval", cnf.WriteBehind.FlushInterval),
+			zap.Int("maxPending", cnf.WriteBehind.MaxPending))
+	}
+	var statsCache *services.CachedStatsRepository
+	if cnf.StatsCache.Enabled {
+		statsCache = services.NewCachedStatsRepository(repo, cnf.StatsCache)
+		repo = statsCache
+		zap.L().Info("stats cache enabled", zap.Duration("ttl", cnf.StatsCache.TTL))
+	}
 
-	app := services.NewRotationService(dbStorage, publisher)
+	app := services.NewRotationService(repo, events, strategy, cnf.Rotation, impressions, cnf.Clicks, cnf.Outbox)
 	grpcServer := server.InitServer(app, cnf.Server)
 
 	wg := sync.WaitGroup{}
 	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		app.RunClicksCleanup(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		app.RunOutboxRelay(ctx)
+	}()
+	if listener, ok := dbStorage.(CatalogChangesListener); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			listener.ListenCatalogChanges(ctx, func(change storage.CatalogChange) {
+				app.ApplyCatalogChange(change)
+				if statsCache != nil {
+					statsCache.Invalidate(change)
+				}
+			})
+		}()
+	}
+	if writeBehind != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			writeBehind.Run(ctx)
+		}()
+	}
+	if cnf.Debug.Port != 0 {
+		debugServer := server.InitDebugServer(cnf.Debug)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			debugServer.Start()
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			stopCtx, cancel := context.WithTimeout(context.Background(), debugServerStopTimeout)
+			defer cancel()
+			debugServer.Stop(stopCtx)
+		}()
+	}
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		grpcServer.Start(stop)
@@ -98,6 +225,27 @@ func mainImpl() error {
 		grpcServer.Stop()
 	}()
 	wg.Wait()
+	if writeBehind != nil {
+		// server is stopped, so no more stats are buffered, write the rest before storage is closed
+		flushCtx, cancel := context.WithTimeout(context.Background(), writeBehindFlushTimeout)
+		defer cancel()
+		if err := writeBehind.Flush(flushCtx); err != nil {
+			zap.L().Error("failed to flush buffered stats on stop", zap.Error(err))
+		}
+	}
 	zap.L().Info("rotation service stopped")
 	return nil
 }
+
+func newStorage(cnf config.DBConfig) (Storage, error) {
+	switch cnf.Type {
+	case "", "postgres":
+		return sql.NewStorage("pgx", cnf), nil
+	case "sqlite":
+		return sqlite.NewStorage(cnf), nil
+	case "memory":
+		return memory.NewStorage(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", cnf.Type)
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/bxcodec/faker/v3 v3.6.0
 	github.com/golang/mock v1.5.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
+	github.com/jackc/pgconn v1.10.0
 	github.com/jackc/pgx/v4 v4.13.0
 	github.com/jmoiron/sqlx v1.3.4
 	github.com/simukti/sqldb-logger v0.0.0-20201125162808-c35f87e285f2
@@ -17,4 +18,5 @@ require (
 	google.golang.org/grpc v1.38.0
 	google.golang.org/grpc/cmd/protoc-gen-go-grpc v1.1.0
 	google.golang.org/protobuf v1.26.0
+	modernc.org/sqlite v1.14.8
 )
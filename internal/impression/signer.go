@@ -0,0 +1,109 @@
+package impression
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid impression token")
+	ErrTokenExpired = errors.New("impression token expired")
+	ErrEmptySecret  = errors.New("impression token secret is empty")
+)
+
+// Impression is a single banner show, clicks can be counted only for impressions issued by the service.
+type Impression struct {
+	ID       string    `json:"id"`
+	SlotID   string    `json:"slotId"`
+	GroupID  string    `json:"groupId"`
+	BannerID string    `json:"bannerId"`
+	ShownAt  time.Time `json:"shownAt"`
+}
+
+// Signer issues and verifies impression tokens.
+// Token is base64 encoded json of Impression and its HMAC-SHA256 signature separated by dot.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewSigner(cnf config.ImpressionConfig) (*Signer, error) {
+	if cnf.Secret == "" {
+		return nil, ErrEmptySecret
+	}
+	return &Signer{
+		secret: []byte(cnf.Secret),
+		ttl:    cnf.TTL,
+		now:    time.Now,
+	}, nil
+}
+
+// TTL returns a time during which token is valid after the show, zero TTL means tokens never expire.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// New creates impression of the banner shown right now with a new random id.
+func (s *Signer) New(slotID, groupID, bannerID string) (Impression, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return Impression{}, fmt.Errorf("error during impression id generation: %w", err)
+	}
+	return Impression{
+		ID:       id,
+		SlotID:   slotID,
+		GroupID:  groupID,
+		BannerID: bannerID,
+		ShownAt:  s.now().UTC(),
+	}, nil
+}
+
+func (s *Signer) Sign(imp Impression) (string, error) {
+	payload, err := json.Marshal(imp)
+	if err != nil {
+		return "", fmt.Errorf("error during marshalling impression: %w", err)
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(s.signature(encodedPayload)), nil
+}
+
+// Verify checks token signature and expiry and returns impression stored in token.
+func (s *Signer) Verify(token string) (Impression, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return Impression{}, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, s.signature(parts[0])) {
+		return Impression{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Impression{}, ErrInvalidToken
+	}
+
+	var imp Impression
+	if err := json.Unmarshal(payload, &imp); err != nil {
+		return Impression{}, ErrInvalidToken
+	}
+	if s.ttl > 0 && s.now().After(imp.ShownAt.Add(s.ttl)) {
+		return Impression{}, ErrTokenExpired
+	}
+	return imp, nil
+}
+
+func (s *Signer) signature(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
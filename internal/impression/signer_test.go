@@ -0,0 +1,65 @@
+package impression
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSigner(config.ImpressionConfig{})
+	require.True(t, errors.Is(err, ErrEmptySecret))
+
+	signer, err := NewSigner(config.ImpressionConfig{Secret: "secret", TTL: time.Hour})
+	require.NoError(t, err)
+
+	imp, err := signer.New("slot", "group", "banner")
+	require.NoError(t, err)
+	require.Len(t, imp.ID, 36)
+
+	token, err := signer.Sign(imp)
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		t.Parallel()
+		verified, err := signer.Verify(token)
+		require.NoError(t, err)
+		require.Equal(t, imp.ID, verified.ID)
+		require.Equal(t, "slot", verified.SlotID)
+		require.Equal(t, "group", verified.GroupID)
+		require.Equal(t, "banner", verified.BannerID)
+		require.True(t, imp.ShownAt.Equal(verified.ShownAt))
+	})
+
+	t.Run("token signed with other secret", func(t *testing.T) {
+		t.Parallel()
+		otherSigner, err := NewSigner(config.ImpressionConfig{Secret: "other secret", TTL: time.Hour})
+		require.NoError(t, err)
+		_, err = otherSigner.Verify(token)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		t.Parallel()
+		for _, malformed := range []string{"", "abc", token + ".abc", "!!!." + token} {
+			_, err := signer.Verify(malformed)
+			require.True(t, errors.Is(err, ErrInvalidToken))
+		}
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		t.Parallel()
+		expiringSigner, err := NewSigner(config.ImpressionConfig{Secret: "secret", TTL: time.Hour})
+		require.NoError(t, err)
+		expiringSigner.now = func() time.Time {
+			return time.Now().Add(2 * time.Hour)
+		}
+		_, err = expiringSigner.Verify(token)
+		require.True(t, errors.Is(err, ErrTokenExpired))
+	})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"go.uber.org/zap"
+)
+
+const debugReadHeaderTimeout = 5 * time.Second
+
+// DebugServer is a http server which serves metrics published to expvar on /debug/vars.
+type DebugServer struct {
+	Srv *http.Server
+}
+
+func InitDebugServer(cnf config.DebugConfig) *DebugServer {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	return &DebugServer{
+		Srv: &http.Server{
+			Addr:              net.JoinHostPort(cnf.Host, strconv.Itoa(cnf.Port)),
+			Handler:           mux,
+			ReadHeaderTimeout: debugReadHeaderTimeout,
+		},
+	}
+}
+
+// Start function is starting debug http server.
+// This function is blocking so it must be called in separate goroutine.
+// Service keeps working if debug server start fails.
+func (s DebugServer) Start() {
+	zap.L().Info("debug server starting...", zap.String("address", s.Srv.Addr))
+	if err := s.Srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		zap.L().Error("Failed to start debug server", zap.Error(err))
+	}
+}
+
+func (s DebugServer) Stop(ctx context.Context) {
+	zap.L().Info("debug server stopping...")
+	if err := s.Srv.Shutdown(ctx); err != nil {
+		zap.L().Error("failed to stop debug server", zap.Error(err))
+		return
+	}
+	zap.L().Info("debug server stopped")
+}
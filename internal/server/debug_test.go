@@ -0,0 +1,35 @@
+package server_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugServerServesMetrics(t *testing.T) {
+	expvar.Publish("debug_server_test_metrics", expvar.Func(func() interface{} {
+		return map[string]int{"published": 3}
+	}))
+	debugServer := server.InitDebugServer(config.DebugConfig{Host: "localhost"})
+	httpServer := httptest.NewServer(debugServer.Srv.Handler)
+	defer httpServer.Close()
+
+	res, err := http.Get(httpServer.URL + "/debug/vars")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	vars := make(map[string]json.RawMessage)
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&vars))
+	require.JSONEq(t, `{"published": 3}`, string(vars["debug_server_test_metrics"]))
+
+	res, err = http.Get(httpServer.URL + "/")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
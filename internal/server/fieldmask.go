@@ -0,0 +1,26 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// descriptionPath is the only updatable field of slots, banners and groups.
+const descriptionPath = "description"
+
+// updatesDescription checks update mask paths and returns whether description should be updated,
+// empty mask means that all updatable fields should be updated.
+func updatesDescription(mask *fieldmaskpb.FieldMask) (bool, error) {
+	if len(mask.GetPaths()) == 0 {
+		return true, nil
+	}
+	update := false
+	for _, path := range mask.GetPaths() {
+		if path != descriptionPath {
+			return false, fmt.Errorf("field %q can't be updated", path)
+		}
+		update = true
+	}
+	return update, nil
+}
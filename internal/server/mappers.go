@@ -1,6 +1,8 @@
 package server
 
 import (
+	"database/sql"
+
 	"github.com/Raschudesny/otus_project/v1/internal/server/pb"
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
 )
@@ -25,3 +27,72 @@ func MapGroupToPb(group storage.SocialGroup) *pb.Group {
 		Description: group.Description,
 	}
 }
+
+func MapSlotBannerToPb(slotBanner storage.SlotBanner) *pb.SlotBanner {
+	return &pb.SlotBanner{
+		SlotId:   slotBanner.SlotID,
+		BannerId: slotBanner.BannerID,
+	}
+}
+
+func MapSlotSettingsToPb(settings storage.SlotSettings) *pb.SlotSettings {
+	res := &pb.SlotSettings{SlotId: settings.SlotID}
+	if settings.Strategy.Valid {
+		res.Strategy = &settings.Strategy.String
+	}
+	if settings.ExplorationConstant.Valid {
+		res.ExplorationConstant = &settings.ExplorationConstant.Float64
+	}
+	if settings.Epsilon.Valid {
+		res.Epsilon = &settings.Epsilon.Float64
+	}
+	if settings.EpsilonDecay.Valid {
+		res.EpsilonDecay = &settings.EpsilonDecay.Float64
+	}
+	if settings.ThompsonAlpha.Valid {
+		res.ThompsonAlpha = &settings.ThompsonAlpha.Float64
+	}
+	if settings.ThompsonBeta.Valid {
+		res.ThompsonBeta = &settings.ThompsonBeta.Float64
+	}
+	if settings.WindowSeconds.Valid {
+		res.WindowSeconds = &settings.WindowSeconds.Int64
+	}
+	return res
+}
+
+func MapPbToSlotSettings(settings *pb.SlotSettings) storage.SlotSettings {
+	res := storage.SlotSettings{SlotID: settings.GetSlotId()}
+	if settings.Strategy != nil {
+		res.Strategy = sql.NullString{String: settings.GetStrategy(), Valid: true}
+	}
+	if settings.ExplorationConstant != nil {
+		res.ExplorationConstant = sql.NullFloat64{Float64: settings.GetExplorationConstant(), Valid: true}
+	}
+	if settings.Epsilon != nil {
+		res.Epsilon = sql.NullFloat64{Float64: settings.GetEpsilon(), Valid: true}
+	}
+	if settings.EpsilonDecay != nil {
+		res.EpsilonDecay = sql.NullFloat64{Float64: settings.GetEpsilonDecay(), Valid: true}
+	}
+	if settings.ThompsonAlpha != nil {
+		res.ThompsonAlpha = sql.NullFloat64{Float64: settings.GetThompsonAlpha(), Valid: true}
+	}
+	if settings.ThompsonBeta != nil {
+		res.ThompsonBeta = sql.NullFloat64{Float64: settings.GetThompsonBeta(), Valid: true}
+	}
+	if settings.WindowSeconds != nil {
+		res.WindowSeconds = sql.NullInt64{Int64: settings.GetWindowSeconds(), Valid: true}
+	}
+	return res
+}
+
+func MapSlotBannerStatToPb(stat storage.SlotBannerStat) *pb.BannerStats {
+	return &pb.BannerStats{
+		BannerId: stat.BannerID,
+		GroupId:  stat.GroupID,
+		Shows:    stat.GetShows(),
+		Clicks:   stat.GetClicks(),
+		Ctr:      stat.GetCTR(),
+	}
+}
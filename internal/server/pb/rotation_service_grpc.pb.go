@@ -23,15 +23,33 @@ type BannerRotationServiceClient interface {
 	DeleteBannerFromSlot(ctx context.Context, in *DeleteBannerFromSlotRequest, opts ...grpc.CallOption) (*DeleteBannerFromSlotResponse, error)
 	AddBanner(ctx context.Context, in *AddBannerRequest, opts ...grpc.CallOption) (*AddBannerResponse, error)
 	DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error)
+	GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*GetBannerResponse, error)
+	UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error)
+	ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error)
 	// API for slots
 	AddSlot(ctx context.Context, in *AddSlotRequest, opts ...grpc.CallOption) (*AddSlotResponse, error)
 	DeleteSlot(ctx context.Context, in *DeleteSlotRequest, opts ...grpc.CallOption) (*DeleteSlotResponse, error)
+	GetSlot(ctx context.Context, in *GetSlotRequest, opts ...grpc.CallOption) (*GetSlotResponse, error)
+	UpdateSlot(ctx context.Context, in *UpdateSlotRequest, opts ...grpc.CallOption) (*UpdateSlotResponse, error)
+	ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error)
+	ListSlotBanners(ctx context.Context, in *ListSlotBannersRequest, opts ...grpc.CallOption) (*ListSlotBannersResponse, error)
+	GetSlotSettings(ctx context.Context, in *GetSlotSettingsRequest, opts ...grpc.CallOption) (*GetSlotSettingsResponse, error)
+	SetSlotSettings(ctx context.Context, in *SetSlotSettingsRequest, opts ...grpc.CallOption) (*SetSlotSettingsResponse, error)
 	// API for groups
 	AddGroup(ctx context.Context, in *AddGroupRequest, opts ...grpc.CallOption) (*AddGroupResponse, error)
 	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
 	// API for rotation
 	PersistClick(ctx context.Context, in *PersistClickRequest, opts ...grpc.CallOption) (*PersistClickResponse, error)
+	// Засчитывает переход по токену показа, повторные переходы по одному показу не засчитываются.
+	PersistImpressionClick(ctx context.Context, in *PersistImpressionClickRequest, opts ...grpc.CallOption) (*PersistImpressionClickResponse, error)
 	NextBanner(ctx context.Context, in *NextBannerRequest, opts ...grpc.CallOption) (*NextBannerResponse, error)
+	NextBanners(ctx context.Context, in *NextBannersRequest, opts ...grpc.CallOption) (*NextBannersResponse, error)
+	NextBannersBatch(ctx context.Context, in *NextBannersBatchRequest, opts ...grpc.CallOption) (*NextBannersBatchResponse, error)
+	// API for stats
+	GetSlotStats(ctx context.Context, in *GetSlotStatsRequest, opts ...grpc.CallOption) (*GetSlotStatsResponse, error)
 }
 
 type bannerRotationServiceClient struct {
@@ -78,6 +96,33 @@ func (c *bannerRotationServiceClient) DeleteBanner(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *bannerRotationServiceClient) GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*GetBannerResponse, error) {
+	out := new(GetBannerResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/GetBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error) {
+	out := new(UpdateBannerResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/UpdateBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error) {
+	out := new(ListBannersResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/ListBanners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bannerRotationServiceClient) AddSlot(ctx context.Context, in *AddSlotRequest, opts ...grpc.CallOption) (*AddSlotResponse, error) {
 	out := new(AddSlotResponse)
 	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/AddSlot", in, out, opts...)
@@ -96,6 +141,60 @@ func (c *bannerRotationServiceClient) DeleteSlot(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *bannerRotationServiceClient) GetSlot(ctx context.Context, in *GetSlotRequest, opts ...grpc.CallOption) (*GetSlotResponse, error) {
+	out := new(GetSlotResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/GetSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) UpdateSlot(ctx context.Context, in *UpdateSlotRequest, opts ...grpc.CallOption) (*UpdateSlotResponse, error) {
+	out := new(UpdateSlotResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/UpdateSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error) {
+	out := new(ListSlotsResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/ListSlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) ListSlotBanners(ctx context.Context, in *ListSlotBannersRequest, opts ...grpc.CallOption) (*ListSlotBannersResponse, error) {
+	out := new(ListSlotBannersResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/ListSlotBanners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) GetSlotSettings(ctx context.Context, in *GetSlotSettingsRequest, opts ...grpc.CallOption) (*GetSlotSettingsResponse, error) {
+	out := new(GetSlotSettingsResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/GetSlotSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) SetSlotSettings(ctx context.Context, in *SetSlotSettingsRequest, opts ...grpc.CallOption) (*SetSlotSettingsResponse, error) {
+	out := new(SetSlotSettingsResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/SetSlotSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bannerRotationServiceClient) AddGroup(ctx context.Context, in *AddGroupRequest, opts ...grpc.CallOption) (*AddGroupResponse, error) {
 	out := new(AddGroupResponse)
 	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/AddGroup", in, out, opts...)
@@ -114,6 +213,33 @@ func (c *bannerRotationServiceClient) DeleteGroup(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *bannerRotationServiceClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	out := new(GetGroupResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error) {
+	out := new(UpdateGroupResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/ListGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bannerRotationServiceClient) PersistClick(ctx context.Context, in *PersistClickRequest, opts ...grpc.CallOption) (*PersistClickResponse, error) {
 	out := new(PersistClickResponse)
 	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/PersistClick", in, out, opts...)
@@ -123,6 +249,15 @@ func (c *bannerRotationServiceClient) PersistClick(ctx context.Context, in *Pers
 	return out, nil
 }
 
+func (c *bannerRotationServiceClient) PersistImpressionClick(ctx context.Context, in *PersistImpressionClickRequest, opts ...grpc.CallOption) (*PersistImpressionClickResponse, error) {
+	out := new(PersistImpressionClickResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/PersistImpressionClick", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bannerRotationServiceClient) NextBanner(ctx context.Context, in *NextBannerRequest, opts ...grpc.CallOption) (*NextBannerResponse, error) {
 	out := new(NextBannerResponse)
 	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/NextBanner", in, out, opts...)
@@ -132,6 +267,33 @@ func (c *bannerRotationServiceClient) NextBanner(ctx context.Context, in *NextBa
 	return out, nil
 }
 
+func (c *bannerRotationServiceClient) NextBanners(ctx context.Context, in *NextBannersRequest, opts ...grpc.CallOption) (*NextBannersResponse, error) {
+	out := new(NextBannersResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/NextBanners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) NextBannersBatch(ctx context.Context, in *NextBannersBatchRequest, opts ...grpc.CallOption) (*NextBannersBatchResponse, error) {
+	out := new(NextBannersBatchResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/NextBannersBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerRotationServiceClient) GetSlotStats(ctx context.Context, in *GetSlotStatsRequest, opts ...grpc.CallOption) (*GetSlotStatsResponse, error) {
+	out := new(GetSlotStatsResponse)
+	err := c.cc.Invoke(ctx, "/banner_rotation.BannerRotationService/GetSlotStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BannerRotationServiceServer is the server API for BannerRotationService service.
 // All implementations must embed UnimplementedBannerRotationServiceServer
 // for forward compatibility
@@ -141,15 +303,33 @@ type BannerRotationServiceServer interface {
 	DeleteBannerFromSlot(context.Context, *DeleteBannerFromSlotRequest) (*DeleteBannerFromSlotResponse, error)
 	AddBanner(context.Context, *AddBannerRequest) (*AddBannerResponse, error)
 	DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error)
+	GetBanner(context.Context, *GetBannerRequest) (*GetBannerResponse, error)
+	UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error)
+	ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error)
 	// API for slots
 	AddSlot(context.Context, *AddSlotRequest) (*AddSlotResponse, error)
 	DeleteSlot(context.Context, *DeleteSlotRequest) (*DeleteSlotResponse, error)
+	GetSlot(context.Context, *GetSlotRequest) (*GetSlotResponse, error)
+	UpdateSlot(context.Context, *UpdateSlotRequest) (*UpdateSlotResponse, error)
+	ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error)
+	ListSlotBanners(context.Context, *ListSlotBannersRequest) (*ListSlotBannersResponse, error)
+	GetSlotSettings(context.Context, *GetSlotSettingsRequest) (*GetSlotSettingsResponse, error)
+	SetSlotSettings(context.Context, *SetSlotSettingsRequest) (*SetSlotSettingsResponse, error)
 	// API for groups
 	AddGroup(context.Context, *AddGroupRequest) (*AddGroupResponse, error)
 	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
 	// API for rotation
 	PersistClick(context.Context, *PersistClickRequest) (*PersistClickResponse, error)
+	// Засчитывает переход по токену показа, повторные переходы по одному показу не засчитываются.
+	PersistImpressionClick(context.Context, *PersistImpressionClickRequest) (*PersistImpressionClickResponse, error)
 	NextBanner(context.Context, *NextBannerRequest) (*NextBannerResponse, error)
+	NextBanners(context.Context, *NextBannersRequest) (*NextBannersResponse, error)
+	NextBannersBatch(context.Context, *NextBannersBatchRequest) (*NextBannersBatchResponse, error)
+	// API for stats
+	GetSlotStats(context.Context, *GetSlotStatsRequest) (*GetSlotStatsResponse, error)
 	mustEmbedUnimplementedBannerRotationServiceServer()
 }
 
@@ -169,24 +349,72 @@ func (UnimplementedBannerRotationServiceServer) AddBanner(context.Context, *AddB
 func (UnimplementedBannerRotationServiceServer) DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBanner not implemented")
 }
+func (UnimplementedBannerRotationServiceServer) GetBanner(context.Context, *GetBannerRequest) (*GetBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBanner not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBanner not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanners not implemented")
+}
 func (UnimplementedBannerRotationServiceServer) AddSlot(context.Context, *AddSlotRequest) (*AddSlotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSlot not implemented")
 }
 func (UnimplementedBannerRotationServiceServer) DeleteSlot(context.Context, *DeleteSlotRequest) (*DeleteSlotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSlot not implemented")
 }
+func (UnimplementedBannerRotationServiceServer) GetSlot(context.Context, *GetSlotRequest) (*GetSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlot not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) UpdateSlot(context.Context, *UpdateSlotRequest) (*UpdateSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSlot not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSlots not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) ListSlotBanners(context.Context, *ListSlotBannersRequest) (*ListSlotBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSlotBanners not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) GetSlotSettings(context.Context, *GetSlotSettingsRequest) (*GetSlotSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlotSettings not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) SetSlotSettings(context.Context, *SetSlotSettingsRequest) (*SetSlotSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSlotSettings not implemented")
+}
 func (UnimplementedBannerRotationServiceServer) AddGroup(context.Context, *AddGroupRequest) (*AddGroupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddGroup not implemented")
 }
 func (UnimplementedBannerRotationServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
 }
+func (UnimplementedBannerRotationServiceServer) GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
 func (UnimplementedBannerRotationServiceServer) PersistClick(context.Context, *PersistClickRequest) (*PersistClickResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PersistClick not implemented")
 }
+func (UnimplementedBannerRotationServiceServer) PersistImpressionClick(context.Context, *PersistImpressionClickRequest) (*PersistImpressionClickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PersistImpressionClick not implemented")
+}
 func (UnimplementedBannerRotationServiceServer) NextBanner(context.Context, *NextBannerRequest) (*NextBannerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NextBanner not implemented")
 }
+func (UnimplementedBannerRotationServiceServer) NextBanners(context.Context, *NextBannersRequest) (*NextBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextBanners not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) NextBannersBatch(context.Context, *NextBannersBatchRequest) (*NextBannersBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextBannersBatch not implemented")
+}
+func (UnimplementedBannerRotationServiceServer) GetSlotStats(context.Context, *GetSlotStatsRequest) (*GetSlotStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlotStats not implemented")
+}
 func (UnimplementedBannerRotationServiceServer) mustEmbedUnimplementedBannerRotationServiceServer() {}
 
 // UnsafeBannerRotationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +500,60 @@ func _BannerRotationService_DeleteBanner_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerRotationService_GetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).GetBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/GetBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).GetBanner(ctx, req.(*GetBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_UpdateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).UpdateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/UpdateBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).UpdateBanner(ctx, req.(*UpdateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_ListBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).ListBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/ListBanners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).ListBanners(ctx, req.(*ListBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BannerRotationService_AddSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddSlotRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +590,114 @@ func _BannerRotationService_DeleteSlot_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerRotationService_GetSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).GetSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/GetSlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).GetSlot(ctx, req.(*GetSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_UpdateSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).UpdateSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/UpdateSlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).UpdateSlot(ctx, req.(*UpdateSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_ListSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).ListSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/ListSlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).ListSlots(ctx, req.(*ListSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_ListSlotBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).ListSlotBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/ListSlotBanners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).ListSlotBanners(ctx, req.(*ListSlotBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_GetSlotSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlotSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).GetSlotSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/GetSlotSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).GetSlotSettings(ctx, req.(*GetSlotSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_SetSlotSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSlotSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).SetSlotSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/SetSlotSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).SetSlotSettings(ctx, req.(*SetSlotSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BannerRotationService_AddGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddGroupRequest)
 	if err := dec(in); err != nil {
@@ -344,6 +734,60 @@ func _BannerRotationService_DeleteGroup_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerRotationService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/ListGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BannerRotationService_PersistClick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PersistClickRequest)
 	if err := dec(in); err != nil {
@@ -362,6 +806,24 @@ func _BannerRotationService_PersistClick_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerRotationService_PersistImpressionClick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PersistImpressionClickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).PersistImpressionClick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/PersistImpressionClick",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).PersistImpressionClick(ctx, req.(*PersistImpressionClickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BannerRotationService_NextBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NextBannerRequest)
 	if err := dec(in); err != nil {
@@ -380,6 +842,60 @@ func _BannerRotationService_NextBanner_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerRotationService_NextBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).NextBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/NextBanners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).NextBanners(ctx, req.(*NextBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_NextBannersBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextBannersBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).NextBannersBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/NextBannersBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).NextBannersBatch(ctx, req.(*NextBannersBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerRotationService_GetSlotStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlotStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerRotationServiceServer).GetSlotStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banner_rotation.BannerRotationService/GetSlotStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerRotationServiceServer).GetSlotStats(ctx, req.(*GetSlotStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BannerRotationService_ServiceDesc is the grpc.ServiceDesc for BannerRotationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -403,6 +919,18 @@ var BannerRotationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBanner",
 			Handler:    _BannerRotationService_DeleteBanner_Handler,
 		},
+		{
+			MethodName: "GetBanner",
+			Handler:    _BannerRotationService_GetBanner_Handler,
+		},
+		{
+			MethodName: "UpdateBanner",
+			Handler:    _BannerRotationService_UpdateBanner_Handler,
+		},
+		{
+			MethodName: "ListBanners",
+			Handler:    _BannerRotationService_ListBanners_Handler,
+		},
 		{
 			MethodName: "AddSlot",
 			Handler:    _BannerRotationService_AddSlot_Handler,
@@ -411,6 +939,30 @@ var BannerRotationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSlot",
 			Handler:    _BannerRotationService_DeleteSlot_Handler,
 		},
+		{
+			MethodName: "GetSlot",
+			Handler:    _BannerRotationService_GetSlot_Handler,
+		},
+		{
+			MethodName: "UpdateSlot",
+			Handler:    _BannerRotationService_UpdateSlot_Handler,
+		},
+		{
+			MethodName: "ListSlots",
+			Handler:    _BannerRotationService_ListSlots_Handler,
+		},
+		{
+			MethodName: "ListSlotBanners",
+			Handler:    _BannerRotationService_ListSlotBanners_Handler,
+		},
+		{
+			MethodName: "GetSlotSettings",
+			Handler:    _BannerRotationService_GetSlotSettings_Handler,
+		},
+		{
+			MethodName: "SetSlotSettings",
+			Handler:    _BannerRotationService_SetSlotSettings_Handler,
+		},
 		{
 			MethodName: "AddGroup",
 			Handler:    _BannerRotationService_AddGroup_Handler,
@@ -419,14 +971,42 @@ var BannerRotationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGroup",
 			Handler:    _BannerRotationService_DeleteGroup_Handler,
 		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _BannerRotationService_GetGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _BannerRotationService_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _BannerRotationService_ListGroups_Handler,
+		},
 		{
 			MethodName: "PersistClick",
 			Handler:    _BannerRotationService_PersistClick_Handler,
 		},
+		{
+			MethodName: "PersistImpressionClick",
+			Handler:    _BannerRotationService_PersistImpressionClick_Handler,
+		},
 		{
 			MethodName: "NextBanner",
 			Handler:    _BannerRotationService_NextBanner_Handler,
 		},
+		{
+			MethodName: "NextBanners",
+			Handler:    _BannerRotationService_NextBanners_Handler,
+		},
+		{
+			MethodName: "NextBannersBatch",
+			Handler:    _BannerRotationService_NextBannersBatch_Handler,
+		},
+		{
+			MethodName: "GetSlotStats",
+			Handler:    _BannerRotationService_GetSlotStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rotation_service.proto",
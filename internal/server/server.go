@@ -6,9 +6,12 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/impression"
 	"github.com/Raschudesny/otus_project/v1/internal/server/pb"
+	"github.com/Raschudesny/otus_project/v1/internal/services"
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	"go.uber.org/zap"
@@ -55,6 +58,130 @@ func (r *RotationService) DeleteSlot(ctx context.Context, req *pb.DeleteSlotRequ
 	}
 }
 
+func (r *RotationService) GetSlot(ctx context.Context, req *pb.GetSlotRequest) (*pb.GetSlotResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlotId())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+	slot, err := r.app.GetSlot(ctx, slotID)
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get slot: %s", err.Error())
+	default:
+		return &pb.GetSlotResponse{Slot: MapSlotToPb(slot)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) UpdateSlot(ctx context.Context, req *pb.UpdateSlotRequest) (*pb.UpdateSlotResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlot().GetId())
+	description := strings.TrimSpace(req.GetSlot().GetDescription())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+	updateDescription, err := updatesDescription(req.GetUpdateMask())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update mask: %s", err.Error())
+	}
+	if updateDescription && description == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "description is empty")
+	}
+
+	var slot storage.Slot
+	if updateDescription {
+		slot, err = r.app.UpdateSlot(ctx, storage.Slot{ID: slotID, Description: description})
+	} else {
+		slot, err = r.app.GetSlot(ctx, slotID)
+	}
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to update slot: %s", err.Error())
+	default:
+		return &pb.UpdateSlotResponse{Slot: MapSlotToPb(slot)}, nil
+	}
+}
+
+func (r *RotationService) ListSlots(ctx context.Context, req *pb.ListSlotsRequest) (*pb.ListSlotsResponse, error) {
+	slots, nextPageToken, err := r.app.ListSlots(ctx, req.GetPageToken(), int(req.GetPageSize()))
+	switch {
+	case errors.Is(err, services.ErrInvalidPageToken):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to list slots: %s", err.Error())
+	}
+	res := &pb.ListSlotsResponse{Slots: make([]*pb.Slot, 0, len(slots)), NextPageToken: nextPageToken}
+	for _, slot := range slots {
+		res.Slots = append(res.Slots, MapSlotToPb(slot))
+	}
+	return res, nil
+}
+
+//nolint:lll
+func (r *RotationService) ListSlotBanners(ctx context.Context, req *pb.ListSlotBannersRequest) (*pb.ListSlotBannersResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlotId())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+	banners, err := r.app.ListSlotBanners(ctx, slotID)
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to list slot banners: %s", err.Error())
+	}
+	res := &pb.ListSlotBannersResponse{Banners: make([]*pb.Banner, 0, len(banners))}
+	for _, banner := range banners {
+		res.Banners = append(res.Banners, MapBannerToPb(banner))
+	}
+	return res, nil
+}
+
+//nolint:lll
+func (r *RotationService) GetSlotSettings(ctx context.Context, req *pb.GetSlotSettingsRequest) (*pb.GetSlotSettingsResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlotId())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+
+	settings, err := r.app.GetSlotSettings(ctx, slotID)
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get slot settings: %s", err.Error())
+	default:
+		return &pb.GetSlotSettingsResponse{Settings: MapSlotSettingsToPb(settings)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) SetSlotSettings(ctx context.Context, req *pb.SetSlotSettingsRequest) (*pb.SetSlotSettingsResponse, error) {
+	if req.GetSettings() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "settings are empty")
+	}
+	settings := MapPbToSlotSettings(req.GetSettings())
+	settings.SlotID = strings.TrimSpace(settings.SlotID)
+	if settings.SlotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+
+	err := r.app.SetSlotSettings(ctx, settings)
+	switch {
+	case errors.Is(err, services.ErrUnknownStrategy), errors.Is(err, services.ErrInvalidStrategyParams):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to set slot settings: %s", err.Error())
+	default:
+		return &pb.SetSlotSettingsResponse{Settings: MapSlotSettingsToPb(settings)}, nil
+	}
+}
+
 //nolint:lll
 func (r *RotationService) AddBannerToSlot(ctx context.Context, req *pb.AddBannerToSlotRequest) (*pb.AddBannerToSlotResponse, error) {
 	slotID := strings.TrimSpace(req.GetSlotId())
@@ -65,10 +192,15 @@ func (r *RotationService) AddBannerToSlot(ctx context.Context, req *pb.AddBanner
 	if bannerID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "banner id is empty")
 	}
-	if err := r.app.AddBannerToSlot(ctx, slotID, bannerID); err != nil {
+	err := r.app.AddBannerToSlot(ctx, slotID, bannerID)
+	switch {
+	case errors.Is(err, storage.ErrSlotToBannerRelationExists):
+		return nil, status.Errorf(codes.AlreadyExists, "banner is already added to the slot")
+	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to add banner to slot: %s", err.Error())
+	default:
+		return &pb.AddBannerToSlotResponse{}, nil
 	}
-	return &pb.AddBannerToSlotResponse{}, nil
 }
 
 //nolint:lll
@@ -111,6 +243,69 @@ func (r *RotationService) DeleteBanner(ctx context.Context, req *pb.DeleteBanner
 	return &pb.DeleteBannerResponse{}, nil
 }
 
+func (r *RotationService) GetBanner(ctx context.Context, req *pb.GetBannerRequest) (*pb.GetBannerResponse, error) {
+	bannerID := strings.TrimSpace(req.GetBannerId())
+	if bannerID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "banner id is empty")
+	}
+	banner, err := r.app.GetBanner(ctx, bannerID)
+	switch {
+	case errors.Is(err, storage.ErrBannerNotFound):
+		return nil, status.Errorf(codes.NotFound, "banner with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get banner: %s", err.Error())
+	default:
+		return &pb.GetBannerResponse{Banner: MapBannerToPb(banner)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) UpdateBanner(ctx context.Context, req *pb.UpdateBannerRequest) (*pb.UpdateBannerResponse, error) {
+	bannerID := strings.TrimSpace(req.GetBanner().GetId())
+	description := strings.TrimSpace(req.GetBanner().GetDescription())
+	if bannerID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "banner id is empty")
+	}
+	updateDescription, err := updatesDescription(req.GetUpdateMask())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update mask: %s", err.Error())
+	}
+	if updateDescription && description == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "description is empty")
+	}
+
+	var banner storage.Banner
+	if updateDescription {
+		banner, err = r.app.UpdateBanner(ctx, storage.Banner{ID: bannerID, Description: description})
+	} else {
+		banner, err = r.app.GetBanner(ctx, bannerID)
+	}
+	switch {
+	case errors.Is(err, storage.ErrBannerNotFound):
+		return nil, status.Errorf(codes.NotFound, "banner with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to update banner: %s", err.Error())
+	default:
+		return &pb.UpdateBannerResponse{Banner: MapBannerToPb(banner)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) ListBanners(ctx context.Context, req *pb.ListBannersRequest) (*pb.ListBannersResponse, error) {
+	banners, nextPageToken, err := r.app.ListBanners(ctx, req.GetPageToken(), int(req.GetPageSize()))
+	switch {
+	case errors.Is(err, services.ErrInvalidPageToken):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to list banners: %s", err.Error())
+	}
+	res := &pb.ListBannersResponse{Banners: make([]*pb.Banner, 0, len(banners)), NextPageToken: nextPageToken}
+	for _, banner := range banners {
+		res.Banners = append(res.Banners, MapBannerToPb(banner))
+	}
+	return res, nil
+}
+
 func (r *RotationService) AddGroup(ctx context.Context, req *pb.AddGroupRequest) (*pb.AddGroupResponse, error) {
 	description := strings.TrimSpace(req.GetDescription())
 	if description == "" {
@@ -135,6 +330,69 @@ func (r *RotationService) DeleteGroup(ctx context.Context, req *pb.DeleteGroupRe
 	return &pb.DeleteGroupResponse{}, nil
 }
 
+func (r *RotationService) GetGroup(ctx context.Context, req *pb.GetGroupRequest) (*pb.GetGroupResponse, error) {
+	groupID := strings.TrimSpace(req.GetGroupId())
+	if groupID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group id is empty")
+	}
+	group, err := r.app.GetGroup(ctx, groupID)
+	switch {
+	case errors.Is(err, storage.ErrGroupNotFound):
+		return nil, status.Errorf(codes.NotFound, "group with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get group: %s", err.Error())
+	default:
+		return &pb.GetGroupResponse{Group: MapGroupToPb(group)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) UpdateGroup(ctx context.Context, req *pb.UpdateGroupRequest) (*pb.UpdateGroupResponse, error) {
+	groupID := strings.TrimSpace(req.GetGroup().GetId())
+	description := strings.TrimSpace(req.GetGroup().GetDescription())
+	if groupID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group id is empty")
+	}
+	updateDescription, err := updatesDescription(req.GetUpdateMask())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update mask: %s", err.Error())
+	}
+	if updateDescription && description == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "description is empty")
+	}
+
+	var group storage.SocialGroup
+	if updateDescription {
+		group, err = r.app.UpdateGroup(ctx, storage.SocialGroup{ID: groupID, Description: description})
+	} else {
+		group, err = r.app.GetGroup(ctx, groupID)
+	}
+	switch {
+	case errors.Is(err, storage.ErrGroupNotFound):
+		return nil, status.Errorf(codes.NotFound, "group with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to update group: %s", err.Error())
+	default:
+		return &pb.UpdateGroupResponse{Group: MapGroupToPb(group)}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	groups, nextPageToken, err := r.app.ListGroups(ctx, req.GetPageToken(), int(req.GetPageSize()))
+	switch {
+	case errors.Is(err, services.ErrInvalidPageToken):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to list groups: %s", err.Error())
+	}
+	res := &pb.ListGroupsResponse{Groups: make([]*pb.Group, 0, len(groups)), NextPageToken: nextPageToken}
+	for _, group := range groups {
+		res.Groups = append(res.Groups, MapGroupToPb(group))
+	}
+	return res, nil
+}
+
 //nolint:lll
 func (r *RotationService) PersistClick(ctx context.Context, req *pb.PersistClickRequest) (*pb.PersistClickResponse, error) {
 	slotID := strings.TrimSpace(req.GetSlotId())
@@ -149,14 +407,41 @@ func (r *RotationService) PersistClick(ctx context.Context, req *pb.PersistClick
 	if bannerID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "banner id is empty")
 	}
-	err := r.app.PersistClick(ctx, slotID, groupID, bannerID)
+	var duplicate bool
+	var err error
+	if idempotencyKey := strings.TrimSpace(req.GetIdempotencyKey()); idempotencyKey != "" {
+		duplicate, err = r.app.PersistClickOnce(ctx, idempotencyKey, slotID, groupID, bannerID)
+	} else {
+		err = r.app.PersistClick(ctx, slotID, groupID, bannerID)
+	}
 	switch {
 	case errors.Is(err, storage.ErrBannerNotShown):
 		return nil, status.Errorf(codes.InvalidArgument, "this banner wasn't shown before, statistics on his clicks are not recorded")
 	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to persist click: %s", err.Error())
 	default:
-		return &pb.PersistClickResponse{}, nil
+		return &pb.PersistClickResponse{Duplicate: duplicate}, nil
+	}
+}
+
+//nolint:lll
+func (r *RotationService) PersistImpressionClick(ctx context.Context, req *pb.PersistImpressionClickRequest) (*pb.PersistImpressionClickResponse, error) {
+	token := strings.TrimSpace(req.GetImpressionToken())
+	if token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "impression token is empty")
+	}
+	err := r.app.PersistImpressionClick(ctx, token)
+	switch {
+	case errors.Is(err, impression.ErrInvalidToken), errors.Is(err, impression.ErrTokenExpired):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrImpressionAlreadyClicked):
+		return nil, status.Errorf(codes.AlreadyExists, "click for this impression is already counted")
+	case errors.Is(err, storage.ErrBannerNotShown):
+		return nil, status.Errorf(codes.InvalidArgument, "this banner wasn't shown before, statistics on his clicks are not recorded")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to persist impression click: %s", err.Error())
+	default:
+		return &pb.PersistImpressionClickResponse{}, nil
 	}
 }
 
@@ -169,11 +454,110 @@ func (r *RotationService) NextBanner(ctx context.Context, req *pb.NextBannerRequ
 	if groupID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "group id is empty")
 	}
-	nextBannerID, err := r.app.NextBannerID(ctx, slotID, groupID)
-	if err != nil {
+	nextBannerID, token, err := r.app.NextBannerID(ctx, slotID, groupID)
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to get next banner to show: %s", err.Error())
 	}
-	return &pb.NextBannerResponse{BannerId: nextBannerID}, nil
+	return &pb.NextBannerResponse{BannerId: nextBannerID, ImpressionToken: token}, nil
+}
+
+//nolint:lll
+func (r *RotationService) NextBanners(ctx context.Context, req *pb.NextBannersRequest) (*pb.NextBannersResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlotId())
+	groupID := strings.TrimSpace(req.GetGroupId())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+	if groupID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group id is empty")
+	}
+	if req.GetCount() == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be positive")
+	}
+	bannerIDs, tokens, err := r.app.NextBannerIDs(ctx, slotID, groupID, int(req.GetCount()))
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get next banners to show: %s", err.Error())
+	}
+	return &pb.NextBannersResponse{BannerIds: bannerIDs, ImpressionTokens: tokens}, nil
+}
+
+//nolint:lll
+func (r *RotationService) NextBannersBatch(ctx context.Context, req *pb.NextBannersBatchRequest) (*pb.NextBannersBatchResponse, error) {
+	groupID := strings.TrimSpace(req.GetGroupId())
+	if groupID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group id is empty")
+	}
+	if len(req.GetSlotIds()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "slot ids are empty")
+	}
+	slotIDs := make([]string, 0, len(req.GetSlotIds()))
+	uniqueSlotIDs := make(map[string]struct{}, len(req.GetSlotIds()))
+	for _, slotID := range req.GetSlotIds() {
+		slotID = strings.TrimSpace(slotID)
+		if slotID == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+		}
+		if _, ok := uniqueSlotIDs[slotID]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "slot id %s is duplicated", slotID)
+		}
+		uniqueSlotIDs[slotID] = struct{}{}
+		slotIDs = append(slotIDs, slotID)
+	}
+
+	slotBanners, tokens, err := r.app.NextBannerIDsForSlots(ctx, slotIDs, groupID)
+	switch {
+	case errors.Is(err, services.ErrTooManySlots):
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get next banners to show: %s", err.Error())
+	}
+	res := &pb.NextBannersBatchResponse{Banners: make([]*pb.SlotBanner, 0, len(slotBanners))}
+	for i, slotBanner := range slotBanners {
+		pbSlotBanner := MapSlotBannerToPb(slotBanner)
+		pbSlotBanner.ImpressionToken = tokens[i]
+		res.Banners = append(res.Banners, pbSlotBanner)
+	}
+	return res, nil
+}
+
+//nolint:lll
+func (r *RotationService) GetSlotStats(ctx context.Context, req *pb.GetSlotStatsRequest) (*pb.GetSlotStatsResponse, error) {
+	slotID := strings.TrimSpace(req.GetSlotId())
+	groupID := strings.TrimSpace(req.GetGroupId())
+	if slotID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slot id is empty")
+	}
+	var from, to time.Time
+	if req.GetFromUnix() != 0 {
+		from = time.Unix(req.GetFromUnix(), 0)
+	}
+	if req.GetToUnix() != 0 {
+		to = time.Unix(req.GetToUnix(), 0)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from must be before to")
+	}
+
+	stats, err := r.app.GetSlotStats(ctx, slotID, groupID, from, to)
+	switch {
+	case errors.Is(err, storage.ErrSlotNotFound):
+		return nil, status.Errorf(codes.NotFound, "slot with provided id not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get slot stats: %s", err.Error())
+	}
+	res := &pb.GetSlotStatsResponse{Stats: make([]*pb.BannerStats, 0, len(stats))}
+	for _, stat := range stats {
+		res.Stats = append(res.Stats, MapSlotBannerStatToPb(stat))
+	}
+	return res, nil
 }
 
 type Server struct {
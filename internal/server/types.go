@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
 )
@@ -9,12 +10,29 @@ import (
 type Application interface {
 	AddSlot(ctx context.Context, description string) (storage.Slot, error)
 	DeleteSlot(ctx context.Context, slotID string) error
+	GetSlot(ctx context.Context, slotID string) (storage.Slot, error)
+	UpdateSlot(ctx context.Context, slot storage.Slot) (storage.Slot, error)
+	ListSlots(ctx context.Context, pageToken string, pageSize int) ([]storage.Slot, string, error)
+	ListSlotBanners(ctx context.Context, slotID string) ([]storage.Banner, error)
+	GetSlotSettings(ctx context.Context, slotID string) (storage.SlotSettings, error)
+	SetSlotSettings(ctx context.Context, settings storage.SlotSettings) error
 	AddBannerToSlot(ctx context.Context, slotID, bannerID string) error
 	DeleteBannerFromSlot(ctx context.Context, bannerID, slotID string) error
 	AddBanner(ctx context.Context, description string) (storage.Banner, error)
 	DeleteBanner(ctx context.Context, bannerID string) error
+	GetBanner(ctx context.Context, bannerID string) (storage.Banner, error)
+	UpdateBanner(ctx context.Context, banner storage.Banner) (storage.Banner, error)
+	ListBanners(ctx context.Context, pageToken string, pageSize int) ([]storage.Banner, string, error)
 	AddGroup(ctx context.Context, description string) (storage.SocialGroup, error)
 	DeleteGroup(ctx context.Context, groupID string) error
+	GetGroup(ctx context.Context, groupID string) (storage.SocialGroup, error)
+	UpdateGroup(ctx context.Context, group storage.SocialGroup) (storage.SocialGroup, error)
+	ListGroups(ctx context.Context, pageToken string, pageSize int) ([]storage.SocialGroup, string, error)
 	PersistClick(ctx context.Context, slotID, groupID, bannerID string) error
-	NextBannerID(ctx context.Context, slotID, groupID string) (string, error)
+	PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string) (bool, error)
+	PersistImpressionClick(ctx context.Context, token string) error
+	NextBannerID(ctx context.Context, slotID, groupID string) (string, string, error)
+	NextBannerIDs(ctx context.Context, slotID, groupID string, count int) ([]string, []string, error)
+	NextBannerIDsForSlots(ctx context.Context, slotIDs []string, groupID string) ([]storage.SlotBanner, []string, error)
+	GetSlotStats(ctx context.Context, slotID, groupID string, from, to time.Time) ([]storage.SlotBannerStat, error)
 }
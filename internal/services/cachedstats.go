@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+type cachedStats struct {
+	stats     []storage.SlotBannerStat
+	expiresAt time.Time
+}
+
+// statsLoad is a version of the slot and group stats being loaded, it's changed by every change of the stats.
+type statsLoad struct {
+	version uint64
+	running int
+}
+
+// CachedStatsRepository is a Repository which keeps slot banners stats in memory (see config.StatsCacheConfig).
+// Shows and clicks stored through it are added to the cached stats immediately,
+// cached stats are reloaded from the underlying repository when TTL is expired.
+// Stats since some time are not cached and are always read from the underlying repository.
+// Stats read in the unit of work are taken from the cache too, slots are locked in this service instance instead
+// of the underlying repository, so units of work of other instances may read the same stats till TTL is expired.
+type CachedStatsRepository struct {
+	Repository
+	ttl   time.Duration
+	mu    sync.Mutex
+	cache map[slotGroupKey]*cachedStats
+	// loads are versions of the stats being loaded, loaded stats are not cached if version is changed,
+	// version is removed when the stats aren't loaded anymore
+	loads     map[slotGroupKey]*statsLoad
+	slotLocks *slotLocks
+}
+
+func NewCachedStatsRepository(repo Repository, cnf config.StatsCacheConfig) *CachedStatsRepository {
+	return &CachedStatsRepository{
+		Repository: repo,
+		ttl:        cnf.TTL,
+		cache:      make(map[slotGroupKey]*cachedStats),
+		loads:      make(map[slotGroupKey]*statsLoad),
+		slotLocks:  newSlotLocks(),
+	}
+}
+
+// get returns copy of the cached stats if they are not expired, expired stats are removed.
+func (c *CachedStatsRepository) get(slotID, groupID string) ([]storage.SlotBannerStat, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := slotGroupKey{slotID: slotID, groupID: groupID}
+	cached, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(cached.expiresAt) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return copyStats(cached.stats), true
+}
+
+// startLoad returns current version of the slot and group stats, it should be called before stats are loaded.
+func (c *CachedStatsRepository) startLoad(slotID, groupID string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := slotGroupKey{slotID: slotID, groupID: groupID}
+	load, ok := c.loads[key]
+	if !ok {
+		load = &statsLoad{}
+		c.loads[key] = load
+	}
+	load.running++
+	return load.version
+}
+
+// finishLoad caches loaded stats if they were not changed since the load was started,
+// so stats loaded before the change don't overwrite the newer ones. Stats which failed to load aren't cached.
+//
+//nolint:lll
+func (c *CachedStatsRepository) finishLoad(slotID, groupID string, version uint64, stats []storage.SlotBannerStat, loaded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := slotGroupKey{slotID: slotID, groupID: groupID}
+	load := c.loads[key]
+	load.running--
+	if load.running == 0 {
+		delete(c.loads, key)
+	}
+	if !loaded || load.version != version {
+		return
+	}
+	c.cache[key] = &cachedStats{
+		stats:     copyStats(stats),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// increase adds clicks and shows to the cached banner stats, if stats of the slot and group are cached.
+func (c *CachedStatsRepository) increase(slotID, groupID, bannerID string, clicks, shows int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := slotGroupKey{slotID: slotID, groupID: groupID}
+	if load, ok := c.loads[key]; ok {
+		load.version++
+	}
+	cached, ok := c.cache[key]
+	if !ok {
+		return
+	}
+	for i := range cached.stats {
+		if cached.stats[i].BannerID != bannerID {
+			continue
+		}
+		cached.stats[i].ClickAmount = sql.NullInt64{Int64: cached.stats[i].GetClicks() + clicks, Valid: true}
+		cached.stats[i].ShowAmount = sql.NullInt64{Int64: cached.stats[i].GetShows() + shows, Valid: true}
+	}
+}
+
+func (c *CachedStatsRepository) invalidateSlot(slotID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.cache {
+		if key.slotID == slotID {
+			delete(c.cache, key)
+		}
+	}
+	for key, load := range c.loads {
+		if key.slotID == slotID {
+			load.version++
+		}
+	}
+}
+
+func (c *CachedStatsRepository) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = make(map[slotGroupKey]*cachedStats)
+	for _, load := range c.loads {
+		load.version++
+	}
+}
+
+// Invalidate drops cached stats affected by the catalog change made by any service instance.
+func (c *CachedStatsRepository) Invalidate(change storage.CatalogChange) {
+	if change.SlotID == "" {
+		c.invalidateAll()
+		return
+	}
+	c.invalidateSlot(change.SlotID)
+}
+
+func copyStats(stats []storage.SlotBannerStat) []storage.SlotBannerStat {
+	return append([]storage.SlotBannerStat(nil), stats...)
+}
+
+// find returns stats of the slots from the cache, stats which are not cached are loaded with load and cached.
+func (c *CachedStatsRepository) find(
+	slotIDs []string,
+	groupID string,
+	load func(slotIDs []string) (map[string][]storage.SlotBannerStat, error),
+) (map[string][]storage.SlotBannerStat, error) {
+	result := make(map[string][]storage.SlotBannerStat, len(slotIDs))
+	var notCached []string
+	versions := make(map[string]uint64)
+	for _, slotID := range slotIDs {
+		if stats, ok := c.get(slotID, groupID); ok {
+			result[slotID] = stats
+			continue
+		}
+		if _, ok := versions[slotID]; ok {
+			continue
+		}
+		notCached = append(notCached, slotID)
+		versions[slotID] = c.startLoad(slotID, groupID)
+	}
+	if len(notCached) == 0 {
+		return result, nil
+	}
+	loaded, err := load(notCached)
+	for _, slotID := range notCached {
+		stats, ok := loaded[slotID]
+		c.finishLoad(slotID, groupID, versions[slotID], stats, err == nil && ok)
+		if ok {
+			result[slotID] = stats
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//nolint:lll
+func (c *CachedStatsRepository) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	stats, err := c.find([]string{slotID}, groupID, func([]string) (map[string][]storage.SlotBannerStat, error) {
+		stats, err := c.Repository.FindSlotBannerStats(ctx, slotID, groupID)
+		return map[string][]storage.SlotBannerStat{slotID: stats}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats[slotID], nil
+}
+
+//nolint:lll
+func (c *CachedStatsRepository) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	return c.find(slotIDs, groupID, func(notCached []string) (map[string][]storage.SlotBannerStat, error) {
+		return c.Repository.FindSlotsBannerStats(ctx, notCached, groupID)
+	})
+}
+
+func (c *CachedStatsRepository) PersistClick(ctx context.Context, slotID, groupID, bannerID string) error {
+	if err := c.Repository.PersistClick(ctx, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	c.increase(slotID, groupID, bannerID, 1, 0)
+	return nil
+}
+
+//nolint:lll
+func (c *CachedStatsRepository) PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error {
+	if err := c.Repository.PersistClickOnce(ctx, idempotencyKey, slotID, groupID, bannerID, window); err != nil {
+		return err
+	}
+	c.increase(slotID, groupID, bannerID, 1, 0)
+	return nil
+}
+
+//nolint:lll
+func (c *CachedStatsRepository) PersistImpressionClick(ctx context.Context, impressionID, slotID, groupID, bannerID string) error {
+	if err := c.Repository.PersistImpressionClick(ctx, impressionID, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	c.increase(slotID, groupID, bannerID, 1, 0)
+	return nil
+}
+
+func (c *CachedStatsRepository) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	if err := c.Repository.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	c.increase(slotID, groupID, bannerID, 0, 1)
+	return nil
+}
+
+func (c *CachedStatsRepository) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	if err := c.Repository.PersistShows(ctx, slotID, groupID, bannerIDs); err != nil {
+		return err
+	}
+	for _, bannerID := range bannerIDs {
+		c.increase(slotID, groupID, bannerID, 0, 1)
+	}
+	return nil
+}
+
+//nolint:lll
+func (c *CachedStatsRepository) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	if err := c.Repository.PersistSlotsShows(ctx, groupID, shows); err != nil {
+		return err
+	}
+	for _, show := range shows {
+		c.increase(show.SlotID, groupID, show.BannerID, 0, 1)
+	}
+	return nil
+}
+
+func (c *CachedStatsRepository) PersistStats(ctx context.Context, deltas []storage.StatsDelta) error {
+	if err := c.Repository.PersistStats(ctx, deltas); err != nil {
+		return err
+	}
+	for _, delta := range deltas {
+		c.increase(delta.SlotID, delta.GroupID, delta.BannerID, delta.Clicks, delta.Shows)
+	}
+	return nil
+}
+
+func (c *CachedStatsRepository) DeleteSlot(ctx context.Context, id string) error {
+	defer c.invalidateSlot(id)
+	return c.Repository.DeleteSlot(ctx, id)
+}
+
+func (c *CachedStatsRepository) AddBannerToSlot(ctx context.Context, slotID, bannerID string) error {
+	defer c.invalidateSlot(slotID)
+	return c.Repository.AddBannerToSlot(ctx, slotID, bannerID)
+}
+
+func (c *CachedStatsRepository) DeleteBannerFromSlot(ctx context.Context, slotID, bannerID string) error {
+	defer c.invalidateSlot(slotID)
+	return c.Repository.DeleteBannerFromSlot(ctx, slotID, bannerID)
+}
+
+func (c *CachedStatsRepository) DeleteBanner(ctx context.Context, id string) error {
+	defer c.invalidateAll()
+	return c.Repository.DeleteBanner(ctx, id)
+}
+
+func (c *CachedStatsRepository) DeleteGroup(ctx context.Context, id string) error {
+	defer c.invalidateAll()
+	return c.Repository.DeleteGroup(ctx, id)
+}
+
+// InStatsTx executes fn in the unit of work of the underlying repository, stats are read in it from the cache.
+// Slots which stats are read by fn from the cache stay locked in this service instance
+// until shows stored by fn are added to the cached stats after the unit of work is finished successfully,
+// so concurrent units of work in the same slot don't choose banner by the same stats.
+func (c *CachedStatsRepository) InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error {
+	locked := make(map[string]struct{})
+	defer func() {
+		for slotID := range locked {
+			c.slotLocks.unlock(slotID)
+		}
+	}()
+
+	var tx *cachedStatsTx
+	if err := c.Repository.InStatsTx(ctx, func(repoTx storage.StatsTx) error {
+		// unit of work may be retried, so shows of the previous attempts are dropped
+		tx = &cachedStatsTx{c: c, tx: repoTx, locked: locked}
+		return fn(tx)
+	}); err != nil {
+		return err
+	}
+	for _, show := range tx.shows {
+		c.increase(show.SlotID, show.GroupID, show.BannerID, 0, 1)
+	}
+	return nil
+}
+
+// cachedStatsTx reads stats from the cache or in the unit of work of the underlying repository if they aren't cached,
+// stores shows in the unit of work and collects them to apply them to the cached stats.
+type cachedStatsTx struct {
+	c      *CachedStatsRepository
+	tx     storage.StatsTx
+	shows  []storage.StatsDelta
+	locked map[string]struct{}
+}
+
+// lock locks the slots in id order in this service instance, slots locked by the previous attempts stay locked.
+func (t *cachedStatsTx) lock(slotIDs ...string) {
+	sorted := append([]string(nil), slotIDs...)
+	sort.Strings(sorted)
+	for _, slotID := range sorted {
+		if _, ok := t.locked[slotID]; ok {
+			continue
+		}
+		t.c.slotLocks.lock(slotID)
+		t.locked[slotID] = struct{}{}
+	}
+}
+
+//nolint:lll
+func (t *cachedStatsTx) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	t.lock(slotID)
+	stats, err := t.c.find([]string{slotID}, groupID, func([]string) (map[string][]storage.SlotBannerStat, error) {
+		stats, err := t.tx.FindSlotBannerStats(ctx, slotID, groupID)
+		return map[string][]storage.SlotBannerStat{slotID: stats}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats[slotID], nil
+}
+
+//nolint:lll
+func (t *cachedStatsTx) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	return t.tx.FindSlotBannerStatsSince(ctx, slotID, groupID, since)
+}
+
+func (t *cachedStatsTx) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	if err := t.tx.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	t.shows = append(t.shows, storage.StatsDelta{SlotID: slotID, GroupID: groupID, BannerID: bannerID, Shows: 1})
+	return nil
+}
+
+func (t *cachedStatsTx) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	if err := t.tx.PersistShows(ctx, slotID, groupID, bannerIDs); err != nil {
+		return err
+	}
+	for _, bannerID := range bannerIDs {
+		t.shows = append(t.shows, storage.StatsDelta{SlotID: slotID, GroupID: groupID, BannerID: bannerID, Shows: 1})
+	}
+	return nil
+}
+
+//nolint:lll
+func (t *cachedStatsTx) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	t.lock(slotIDs...)
+	return t.c.find(slotIDs, groupID, func(notCached []string) (map[string][]storage.SlotBannerStat, error) {
+		return t.tx.FindSlotsBannerStats(ctx, notCached, groupID)
+	})
+}
+
+//nolint:lll
+func (t *cachedStatsTx) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	return t.tx.FindSlotsBannerStatsSince(ctx, slotIDs, groupID, since)
+}
+
+func (t *cachedStatsTx) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	if err := t.tx.PersistSlotsShows(ctx, groupID, shows); err != nil {
+		return err
+	}
+	for _, show := range shows {
+		t.shows = append(t.shows, storage.StatsDelta{SlotID: show.SlotID, GroupID: groupID, BannerID: show.BannerID, Shows: 1})
+	}
+	return nil
+}
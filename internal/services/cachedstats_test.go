@@ -0,0 +1,207 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/services"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/memory"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedStats(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	cache := services.NewCachedStatsRepository(mockRepo, config.StatsCacheConfig{TTL: time.Hour})
+	dbStats := []storage.SlotBannerStat{
+		{
+			SlotID:      "slot",
+			BannerID:    "banner",
+			ClickAmount: sql.NullInt64{Int64: 1, Valid: true},
+			ShowAmount:  sql.NullInt64{Int64: 10, Valid: true},
+		},
+	}
+
+	// stats are read from the repository only once
+	mockRepo.EXPECT().FindSlotBannerStats(ctx, "slot", "group").Times(1).Return(dbStats, nil)
+	stats, err := cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+	require.Equal(t, dbStats, stats)
+
+	// local shows and clicks are applied to the cached stats
+	mockRepo.EXPECT().PersistShow(ctx, "slot", "group", "banner").Return(nil)
+	mockRepo.EXPECT().PersistClick(ctx, "slot", "group", "banner").Return(nil)
+	require.NoError(t, cache.PersistShow(ctx, "slot", "group", "banner"))
+	require.NoError(t, cache.PersistClick(ctx, "slot", "group", "banner"))
+	stats, err = cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+	require.Equal(t, int64(11), stats[0].GetShows())
+	require.Equal(t, int64(2), stats[0].GetClicks())
+
+	// stats are read in the unit of work from the cache, shows are applied after the unit of work
+	mockRepo.EXPECT().InStatsTx(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(tx storage.StatsTx) error) error {
+			return fn(mockRepo)
+		})
+	mockRepo.EXPECT().PersistShows(ctx, "slot", "group", []string{"banner", "banner"}).Return(nil)
+	err = cache.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		stats, err := tx.FindSlotBannerStats(ctx, "slot", "group")
+		require.NoError(t, err)
+		require.Equal(t, int64(11), stats[0].GetShows())
+		return tx.PersistShows(ctx, "slot", "group", []string{"banner", "banner"})
+	})
+	require.NoError(t, err)
+	stats, err = cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+	require.Equal(t, int64(13), stats[0].GetShows())
+
+	// slot banners change invalidates the cached stats
+	mockRepo.EXPECT().AddBannerToSlot(ctx, "slot", "other banner").Return(nil)
+	require.NoError(t, cache.AddBannerToSlot(ctx, "slot", "other banner"))
+	mockRepo.EXPECT().FindSlotBannerStats(ctx, "slot", "group").Times(1).Return(dbStats, nil)
+	stats, err = cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+	require.Equal(t, dbStats, stats)
+}
+
+func TestCachedStatsTxLoadsNotCached(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	cache := services.NewCachedStatsRepository(mockRepo, config.StatsCacheConfig{TTL: time.Hour})
+	dbStats := map[string][]storage.SlotBannerStat{
+		"slot": {{SlotID: "slot", BannerID: "banner", ShowAmount: sql.NullInt64{Int64: 10, Valid: true}}},
+	}
+
+	// stats which are not cached are read in the unit of work and cached after it
+	mockRepo.EXPECT().InStatsTx(ctx, gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, fn func(tx storage.StatsTx) error) error {
+			return fn(mockRepo)
+		})
+	mockRepo.EXPECT().FindSlotsBannerStats(ctx, []string{"slot"}, "group").Times(1).Return(dbStats, nil)
+	mockRepo.EXPECT().PersistSlotsShows(ctx, "group", gomock.Any()).Times(2).Return(nil)
+	for _, expectedShows := range []int64{10, 11} {
+		err := cache.InStatsTx(ctx, func(tx storage.StatsTx) error {
+			stats, err := tx.FindSlotsBannerStats(ctx, []string{"slot"}, "group")
+			require.NoError(t, err)
+			require.Equal(t, expectedShows, stats["slot"][0].GetShows())
+			return tx.PersistSlotsShows(ctx, "group", []storage.SlotBanner{{SlotID: "slot", BannerID: "banner"}})
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestCachedStatsExpiration(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	cache := services.NewCachedStatsRepository(mockRepo, config.StatsCacheConfig{TTL: time.Millisecond * 50})
+	dbStats := []storage.SlotBannerStat{{SlotID: "slot", BannerID: "banner"}}
+
+	mockRepo.EXPECT().FindSlotsBannerStats(ctx, []string{"slot"}, "group").Times(2).
+		Return(map[string][]storage.SlotBannerStat{"slot": dbStats}, nil)
+	for i := 0; i < 2; i++ {
+		stats, err := cache.FindSlotsBannerStats(ctx, []string{"slot"}, "group")
+		require.NoError(t, err)
+		require.Equal(t, dbStats, stats["slot"])
+		stats, err = cache.FindSlotsBannerStats(ctx, []string{"slot"}, "group")
+		require.NoError(t, err)
+		require.Equal(t, dbStats, stats["slot"])
+		time.Sleep(time.Millisecond * 100)
+	}
+}
+
+func TestCachedStatsInvalidate(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	cache := services.NewCachedStatsRepository(mockRepo, config.StatsCacheConfig{TTL: time.Hour})
+	dbStats := []storage.SlotBannerStat{{SlotID: "slot", BannerID: "banner"}}
+
+	// stats are reloaded after changes of the slot or unknown changes only
+	mockRepo.EXPECT().FindSlotBannerStats(ctx, "slot", "group").Times(3).Return(dbStats, nil)
+	changes := []storage.CatalogChange{
+		{SlotID: "other slot", BannerID: "banner"},
+		{SlotID: "slot", BannerID: "banner"},
+		{},
+	}
+	for _, change := range changes {
+		_, err := cache.FindSlotBannerStats(ctx, "slot", "group")
+		require.NoError(t, err)
+		cache.Invalidate(change)
+	}
+	_, err := cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+}
+
+// TestCachedStatsLoadedBeforeChange checks that stats loaded before the change don't overwrite the changed ones.
+func TestCachedStatsLoadedBeforeChange(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	cache := services.NewCachedStatsRepository(mockRepo, config.StatsCacheConfig{TTL: time.Hour})
+	dbStats := []storage.SlotBannerStat{{SlotID: "slot", BannerID: "banner"}}
+
+	// show is stored while the stats are being loaded
+	mockRepo.EXPECT().PersistShow(ctx, "slot", "group", "banner").Return(nil)
+	mockRepo.EXPECT().FindSlotBannerStats(ctx, "slot", "group").DoAndReturn(
+		func(context.Context, string, string) ([]storage.SlotBannerStat, error) {
+			require.NoError(t, cache.PersistShow(ctx, "slot", "group", "banner"))
+			return dbStats, nil
+		})
+	_, err := cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+
+	// so loaded stats aren't cached
+	mockRepo.EXPECT().FindSlotBannerStats(ctx, "slot", "group").Times(1).Return(dbStats, nil)
+	_, err = cache.FindSlotBannerStats(ctx, "slot", "group")
+	require.NoError(t, err)
+}
+
+// TestCachedStatsTxIsolation checks that concurrent units of work never read the same stats
+// even if the stats are cached.
+func TestCachedStatsTxIsolation(t *testing.T) {
+	ctx := context.Background()
+	repo := slowTxRepo{Storage: memory.NewStorage()}
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo.Storage)
+	cache := services.NewCachedStatsRepository(repo, config.StatsCacheConfig{TTL: time.Hour})
+	_, err := cache.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+
+	const workers = 50
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	seenShows := make(map[int64]struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shows int64
+			err := cache.InStatsTx(ctx, func(tx storage.StatsTx) error {
+				stats, err := tx.FindSlotBannerStats(ctx, slotID, groupID)
+				if err != nil {
+					return err
+				}
+				shows = stats[0].GetShows()
+				return tx.PersistShow(ctx, slotID, groupID, bannerID)
+			})
+			require.NoError(t, err)
+			mu.Lock()
+			seenShows[shows] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seenShows, workers)
+
+	stats, err := cache.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(workers), stats[0].GetShows())
+}
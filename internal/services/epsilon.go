@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+const (
+	EpsilonGreedyStrategyName         = "epsilon-greedy"
+	DecayingEpsilonGreedyStrategyName = "decaying-epsilon-greedy"
+)
+
+var _ Strategy = (*EpsilonGreedyStrategy)(nil)
+
+// EpsilonGreedyStrategy is a Strategy which with probability epsilon shows random banner (exploration)
+// and otherwise shows banner with the best clicks to shows ratio (exploitation).
+// If decay is positive, epsilon decreases with total shows amount n as epsilon / (1 + decay * n),
+// so the more statistics is collected for a slot and group, the less exploration is done.
+type EpsilonGreedyStrategy struct {
+	epsilon float64
+	decay   float64
+	rnd     *lockedRand
+}
+
+// NewEpsilonGreedyStrategy creates epsilon-greedy strategy, zero decay means fixed epsilon.
+// Zero seed means that random generator is seeded with the current time.
+func NewEpsilonGreedyStrategy(epsilon, decay float64, seed int64) (*EpsilonGreedyStrategy, error) {
+	if math.IsNaN(epsilon) || epsilon < 0 || epsilon > 1 {
+		return nil, fmt.Errorf("%w: epsilon must be in [0, 1] range, got %v", ErrInvalidStrategyParams, epsilon)
+	}
+	if math.IsNaN(decay) || decay < 0 {
+		return nil, fmt.Errorf("%w: epsilon decay must be non negative, got %v", ErrInvalidStrategyParams, decay)
+	}
+	return &EpsilonGreedyStrategy{
+		epsilon: epsilon,
+		decay:   decay,
+		rnd:     newLockedRand(seed),
+	}, nil
+}
+
+func newEpsilonGreedyStrategy(cnf config.RotationConfig) (Strategy, error) {
+	return NewEpsilonGreedyStrategy(cnf.Epsilon, 0, cnf.Seed)
+}
+
+func newDecayingEpsilonGreedyStrategy(cnf config.RotationConfig) (Strategy, error) {
+	if math.IsNaN(cnf.EpsilonDecay) || cnf.EpsilonDecay <= 0 {
+		return nil, fmt.Errorf("%w: epsilon decay must be positive for decaying strategy, got %v",
+			ErrInvalidStrategyParams, cnf.EpsilonDecay)
+	}
+	return NewEpsilonGreedyStrategy(cnf.Epsilon, cnf.EpsilonDecay, cnf.Seed)
+}
+
+func (e *EpsilonGreedyStrategy) NextBannerID(_ context.Context, bannerStats []storage.SlotBannerStat) string {
+	if e.rnd.float64() < e.currentEpsilon(countTotalShowsAmount(bannerStats)) {
+		return bannerStats[e.rnd.intn(len(bannerStats))].BannerID
+	}
+
+	// all available banners should be shown at least once before comparing their ctr
+	if bannerID, ok := findNotShownBanner(bannerStats); ok {
+		return bannerID
+	}
+
+	maxCTR := -1.0
+	maxBannerID := bannerStats[0].BannerID
+	for _, bannerStat := range bannerStats {
+		ctr := float64(bannerStat.GetClicks()) / float64(bannerStat.GetShows())
+		if ctr > maxCTR {
+			maxCTR = ctr
+			maxBannerID = bannerStat.BannerID
+		}
+	}
+	return maxBannerID
+}
+
+func (e *EpsilonGreedyStrategy) currentEpsilon(totalShows int64) float64 {
+	return e.epsilon / (1 + e.decay*float64(totalShows))
+}
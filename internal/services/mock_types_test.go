@@ -7,6 +7,7 @@ package services_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	stats "github.com/Raschudesny/otus_project/v1/internal/stats"
 	storage "github.com/Raschudesny/otus_project/v1/internal/storage"
@@ -95,6 +96,21 @@ func (mr *MockRepositoryMockRecorder) AddSlot(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSlot", reflect.TypeOf((*MockRepository)(nil).AddSlot), arg0, arg1)
 }
 
+// ClaimOutboxEvents mocks base method.
+func (m *MockRepository) ClaimOutboxEvents(arg0 context.Context, arg1 int, arg2 time.Duration) ([]storage.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]storage.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockRepositoryMockRecorder) ClaimOutboxEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockRepository)(nil).ClaimOutboxEvents), arg0, arg1, arg2)
+}
+
 // DeleteBanner mocks base method.
 func (m *MockRepository) DeleteBanner(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +139,36 @@ func (mr *MockRepositoryMockRecorder) DeleteBannerFromSlot(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBannerFromSlot", reflect.TypeOf((*MockRepository)(nil).DeleteBannerFromSlot), arg0, arg1, arg2)
 }
 
+// DeleteExpiredClickKeys mocks base method.
+func (m *MockRepository) DeleteExpiredClickKeys(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredClickKeys", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredClickKeys indicates an expected call of DeleteExpiredClickKeys.
+func (mr *MockRepositoryMockRecorder) DeleteExpiredClickKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredClickKeys", reflect.TypeOf((*MockRepository)(nil).DeleteExpiredClickKeys), arg0, arg1)
+}
+
+// DeleteExpiredImpressionClicks mocks base method.
+func (m *MockRepository) DeleteExpiredImpressionClicks(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredImpressionClicks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredImpressionClicks indicates an expected call of DeleteExpiredImpressionClicks.
+func (mr *MockRepositoryMockRecorder) DeleteExpiredImpressionClicks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredImpressionClicks", reflect.TypeOf((*MockRepository)(nil).DeleteExpiredImpressionClicks), arg0, arg1)
+}
+
 // DeleteGroup mocks base method.
 func (m *MockRepository) DeleteGroup(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -137,6 +183,21 @@ func (mr *MockRepositoryMockRecorder) DeleteGroup(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockRepository)(nil).DeleteGroup), arg0, arg1)
 }
 
+// DeleteSentOutboxEvents mocks base method.
+func (m *MockRepository) DeleteSentOutboxEvents(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSentOutboxEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSentOutboxEvents indicates an expected call of DeleteSentOutboxEvents.
+func (mr *MockRepositoryMockRecorder) DeleteSentOutboxEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSentOutboxEvents", reflect.TypeOf((*MockRepository)(nil).DeleteSentOutboxEvents), arg0, arg1)
+}
+
 // DeleteSlot mocks base method.
 func (m *MockRepository) DeleteSlot(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -166,6 +227,81 @@ func (mr *MockRepositoryMockRecorder) FindSlotBannerStats(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlotBannerStats", reflect.TypeOf((*MockRepository)(nil).FindSlotBannerStats), arg0, arg1, arg2)
 }
 
+// FindSlotBannerStatsSince mocks base method.
+func (m *MockRepository) FindSlotBannerStatsSince(arg0 context.Context, arg1, arg2 string, arg3 time.Time) ([]storage.SlotBannerStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlotBannerStatsSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]storage.SlotBannerStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlotBannerStatsSince indicates an expected call of FindSlotBannerStatsSince.
+func (mr *MockRepositoryMockRecorder) FindSlotBannerStatsSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlotBannerStatsSince", reflect.TypeOf((*MockRepository)(nil).FindSlotBannerStatsSince), arg0, arg1, arg2, arg3)
+}
+
+// FindSlotBanners mocks base method.
+func (m *MockRepository) FindSlotBanners(arg0 context.Context, arg1 string) ([]storage.Banner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlotBanners", arg0, arg1)
+	ret0, _ := ret[0].([]storage.Banner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlotBanners indicates an expected call of FindSlotBanners.
+func (mr *MockRepositoryMockRecorder) FindSlotBanners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlotBanners", reflect.TypeOf((*MockRepository)(nil).FindSlotBanners), arg0, arg1)
+}
+
+// FindSlotsBannerStats mocks base method.
+func (m *MockRepository) FindSlotsBannerStats(arg0 context.Context, arg1 []string, arg2 string) (map[string][]storage.SlotBannerStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlotsBannerStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]storage.SlotBannerStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlotsBannerStats indicates an expected call of FindSlotsBannerStats.
+func (mr *MockRepositoryMockRecorder) FindSlotsBannerStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlotsBannerStats", reflect.TypeOf((*MockRepository)(nil).FindSlotsBannerStats), arg0, arg1, arg2)
+}
+
+// FindSlotsBannerStatsSince mocks base method.
+func (m *MockRepository) FindSlotsBannerStatsSince(arg0 context.Context, arg1 []string, arg2 string, arg3 time.Time) (map[string][]storage.SlotBannerStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlotsBannerStatsSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string][]storage.SlotBannerStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlotsBannerStatsSince indicates an expected call of FindSlotsBannerStatsSince.
+func (mr *MockRepositoryMockRecorder) FindSlotsBannerStatsSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlotsBannerStatsSince", reflect.TypeOf((*MockRepository)(nil).FindSlotsBannerStatsSince), arg0, arg1, arg2, arg3)
+}
+
+// FindStatsBuckets mocks base method.
+func (m *MockRepository) FindStatsBuckets(arg0 context.Context, arg1 storage.StatsBucketsFilter) ([]storage.StatsBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatsBuckets", arg0, arg1)
+	ret0, _ := ret[0].([]storage.StatsBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStatsBuckets indicates an expected call of FindStatsBuckets.
+func (mr *MockRepositoryMockRecorder) FindStatsBuckets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatsBuckets", reflect.TypeOf((*MockRepository)(nil).FindStatsBuckets), arg0, arg1)
+}
+
 // GetBannerByID mocks base method.
 func (m *MockRepository) GetBannerByID(arg0 context.Context, arg1 string) (storage.Banner, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +347,109 @@ func (mr *MockRepositoryMockRecorder) GetSlotByID(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotByID", reflect.TypeOf((*MockRepository)(nil).GetSlotByID), arg0, arg1)
 }
 
+// GetSlotSettings mocks base method.
+func (m *MockRepository) GetSlotSettings(arg0 context.Context, arg1 string) (storage.SlotSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotSettings", arg0, arg1)
+	ret0, _ := ret[0].(storage.SlotSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotSettings indicates an expected call of GetSlotSettings.
+func (mr *MockRepositoryMockRecorder) GetSlotSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotSettings", reflect.TypeOf((*MockRepository)(nil).GetSlotSettings), arg0, arg1)
+}
+
+// GetSlotsSettings mocks base method.
+func (m *MockRepository) GetSlotsSettings(arg0 context.Context, arg1 []string) ([]storage.SlotSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotsSettings", arg0, arg1)
+	ret0, _ := ret[0].([]storage.SlotSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotsSettings indicates an expected call of GetSlotsSettings.
+func (mr *MockRepositoryMockRecorder) GetSlotsSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotsSettings", reflect.TypeOf((*MockRepository)(nil).GetSlotsSettings), arg0, arg1)
+}
+
+// InStatsTx mocks base method.
+func (m *MockRepository) InStatsTx(arg0 context.Context, arg1 func(storage.StatsTx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InStatsTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InStatsTx indicates an expected call of InStatsTx.
+func (mr *MockRepositoryMockRecorder) InStatsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InStatsTx", reflect.TypeOf((*MockRepository)(nil).InStatsTx), arg0, arg1)
+}
+
+// ListBanners mocks base method.
+func (m *MockRepository) ListBanners(arg0 context.Context, arg1 string, arg2 int) ([]storage.Banner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBanners", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]storage.Banner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBanners indicates an expected call of ListBanners.
+func (mr *MockRepositoryMockRecorder) ListBanners(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBanners", reflect.TypeOf((*MockRepository)(nil).ListBanners), arg0, arg1, arg2)
+}
+
+// ListGroups mocks base method.
+func (m *MockRepository) ListGroups(arg0 context.Context, arg1 string, arg2 int) ([]storage.SocialGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]storage.SocialGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockRepositoryMockRecorder) ListGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockRepository)(nil).ListGroups), arg0, arg1, arg2)
+}
+
+// ListSlots mocks base method.
+func (m *MockRepository) ListSlots(arg0 context.Context, arg1 string, arg2 int) ([]storage.Slot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSlots", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]storage.Slot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSlots indicates an expected call of ListSlots.
+func (mr *MockRepositoryMockRecorder) ListSlots(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSlots", reflect.TypeOf((*MockRepository)(nil).ListSlots), arg0, arg1, arg2)
+}
+
+// MarkOutboxEventsSent mocks base method.
+func (m *MockRepository) MarkOutboxEventsSent(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventsSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventsSent indicates an expected call of MarkOutboxEventsSent.
+func (mr *MockRepositoryMockRecorder) MarkOutboxEventsSent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventsSent", reflect.TypeOf((*MockRepository)(nil).MarkOutboxEventsSent), arg0, arg1)
+}
+
 // PersistClick mocks base method.
 func (m *MockRepository) PersistClick(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -225,6 +464,34 @@ func (mr *MockRepositoryMockRecorder) PersistClick(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistClick", reflect.TypeOf((*MockRepository)(nil).PersistClick), arg0, arg1, arg2, arg3)
 }
 
+// PersistClickOnce mocks base method.
+func (m *MockRepository) PersistClickOnce(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistClickOnce", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistClickOnce indicates an expected call of PersistClickOnce.
+func (mr *MockRepositoryMockRecorder) PersistClickOnce(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistClickOnce", reflect.TypeOf((*MockRepository)(nil).PersistClickOnce), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// PersistImpressionClick mocks base method.
+func (m *MockRepository) PersistImpressionClick(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistImpressionClick", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistImpressionClick indicates an expected call of PersistImpressionClick.
+func (mr *MockRepositoryMockRecorder) PersistImpressionClick(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistImpressionClick", reflect.TypeOf((*MockRepository)(nil).PersistImpressionClick), arg0, arg1, arg2, arg3, arg4)
+}
+
 // PersistShow mocks base method.
 func (m *MockRepository) PersistShow(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -239,6 +506,104 @@ func (mr *MockRepositoryMockRecorder) PersistShow(arg0, arg1, arg2, arg3 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistShow", reflect.TypeOf((*MockRepository)(nil).PersistShow), arg0, arg1, arg2, arg3)
 }
 
+// PersistShows mocks base method.
+func (m *MockRepository) PersistShows(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistShows", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistShows indicates an expected call of PersistShows.
+func (mr *MockRepositoryMockRecorder) PersistShows(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistShows", reflect.TypeOf((*MockRepository)(nil).PersistShows), arg0, arg1, arg2, arg3)
+}
+
+// PersistSlotsShows mocks base method.
+func (m *MockRepository) PersistSlotsShows(arg0 context.Context, arg1 string, arg2 []storage.SlotBanner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistSlotsShows", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistSlotsShows indicates an expected call of PersistSlotsShows.
+func (mr *MockRepositoryMockRecorder) PersistSlotsShows(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistSlotsShows", reflect.TypeOf((*MockRepository)(nil).PersistSlotsShows), arg0, arg1, arg2)
+}
+
+// PersistStats mocks base method.
+func (m *MockRepository) PersistStats(arg0 context.Context, arg1 []storage.StatsDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistStats indicates an expected call of PersistStats.
+func (mr *MockRepositoryMockRecorder) PersistStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistStats", reflect.TypeOf((*MockRepository)(nil).PersistStats), arg0, arg1)
+}
+
+// SetSlotSettings mocks base method.
+func (m *MockRepository) SetSlotSettings(arg0 context.Context, arg1 storage.SlotSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSlotSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSlotSettings indicates an expected call of SetSlotSettings.
+func (mr *MockRepositoryMockRecorder) SetSlotSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSlotSettings", reflect.TypeOf((*MockRepository)(nil).SetSlotSettings), arg0, arg1)
+}
+
+// UpdateBanner mocks base method.
+func (m *MockRepository) UpdateBanner(arg0 context.Context, arg1 storage.Banner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBanner", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBanner indicates an expected call of UpdateBanner.
+func (mr *MockRepositoryMockRecorder) UpdateBanner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBanner", reflect.TypeOf((*MockRepository)(nil).UpdateBanner), arg0, arg1)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockRepository) UpdateGroup(arg0 context.Context, arg1 storage.SocialGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockRepositoryMockRecorder) UpdateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockRepository)(nil).UpdateGroup), arg0, arg1)
+}
+
+// UpdateSlot mocks base method.
+func (m *MockRepository) UpdateSlot(arg0 context.Context, arg1 storage.Slot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSlot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSlot indicates an expected call of UpdateSlot.
+func (mr *MockRepositoryMockRecorder) UpdateSlot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSlot", reflect.TypeOf((*MockRepository)(nil).UpdateSlot), arg0, arg1)
+}
+
 // MockEventsPublisher is a mock of EventsPublisher interface.
 type MockEventsPublisher struct {
 	ctrl     *gomock.Controller
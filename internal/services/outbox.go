@@ -0,0 +1,39 @@
+package services
+
+import "sync"
+
+// maxConfirmedBatches is an amount of outbox batches whose confirmed events are kept until they are marked as sent.
+const maxConfirmedBatches = 100
+
+// confirmedEvents collects ids of outbox events confirmed by the broker until they are marked as sent.
+// Not more than limit ids are kept, the oldest ones are dropped first: their lease is likely over,
+// so they are published again anyway.
+type confirmedEvents struct {
+	mu    sync.Mutex
+	ids   []string
+	limit int
+}
+
+func newConfirmedEvents(limit int) *confirmedEvents {
+	if limit < 1 {
+		limit = 1
+	}
+	return &confirmedEvents{limit: limit}
+}
+
+func (c *confirmedEvents) add(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids = append(c.ids, ids...)
+	if overflow := len(c.ids) - c.limit; overflow > 0 {
+		c.ids = append([]string(nil), c.ids[overflow:]...)
+	}
+}
+
+func (c *confirmedEvents) take() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := c.ids
+	c.ids = nil
+	return ids
+}
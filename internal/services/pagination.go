@@ -0,0 +1,46 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is an opaque cursor with id of the last entity of the previous page.
+func encodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastID))
+}
+
+// decodePageToken returns id after which the page starts, empty token means the first page.
+// Token should contain a valid id, otherwise storage would fail on the id comparison.
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	lastID, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !storage.IsValidID(string(lastID)) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPageToken, token)
+	}
+	return string(lastID), nil
+}
+
+// pageLimit returns page size which should be used for the requested one.
+func pageLimit(pageSize int) int {
+	switch {
+	case pageSize <= 0:
+		return DefaultPageSize
+	case pageSize > MaxPageSize:
+		return MaxPageSize
+	default:
+		return pageSize
+	}
+}
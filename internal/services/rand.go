@@ -0,0 +1,77 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedRand is a random generator safe for concurrent use.
+type lockedRand struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func newLockedRand(seed int64) *lockedRand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	//nolint:gosec
+	return &lockedRand{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (l *lockedRand) float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rnd.Float64()
+}
+
+func (l *lockedRand) intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rnd.Intn(n)
+}
+
+// beta returns Beta(a, b) distributed value, calculated as X / (X + Y)
+// where X ~ Gamma(a, 1) and Y ~ Gamma(b, 1). Zero is returned if a or b isn't positive.
+func (l *lockedRand) beta(a, b float64) float64 {
+	// gamma sampling never ends for NaN shape
+	if math.IsNaN(a) || math.IsNaN(b) || a <= 0 || b <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	x := gamma(l.rnd, a)
+	y := gamma(l.rnd, b)
+	if x+y == 0 {
+		return 0
+	}
+	return x / (x + y)
+}
+
+// gamma returns Gamma(shape, 1) distributed value using Marsaglia and Tsang method.
+// Original paper: https://dl.acm.org/doi/10.1145/358407.358414.
+func gamma(rnd *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// Gamma(shape) = Gamma(shape + 1) * U^(1 / shape)
+		return gamma(rnd, shape+1) * math.Pow(rnd.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rnd.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rnd.Float64()
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
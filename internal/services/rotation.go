@@ -2,45 +2,128 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"math"
-	"math/big"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/impression"
 	"github.com/Raschudesny/otus_project/v1/internal/stats"
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"go.uber.org/zap"
 )
 
+// ErrTooManySlots is returned when banners are requested for more slots than allowed at once.
+var ErrTooManySlots = errors.New("too many slots requested")
+
 //nolint:lll
 //go:generate mockgen --build_flags=--mod=mod -destination=./mock_types_test.go -package=services_test . Repository,EventsPublisher
 type Repository interface {
 	AddSlot(ctx context.Context, description string) (string, error)
 	GetSlotByID(ctx context.Context, id string) (storage.Slot, error)
+	UpdateSlot(ctx context.Context, slot storage.Slot) error
 	DeleteSlot(ctx context.Context, id string) error
+	GetSlotSettings(ctx context.Context, slotID string) (storage.SlotSettings, error)
+	GetSlotsSettings(ctx context.Context, slotIDs []string) ([]storage.SlotSettings, error)
+	SetSlotSettings(ctx context.Context, settings storage.SlotSettings) error
 	AddBanner(ctx context.Context, description string) (string, error)
 	GetBannerByID(ctx context.Context, id string) (storage.Banner, error)
+	UpdateBanner(ctx context.Context, banner storage.Banner) error
 	DeleteBanner(ctx context.Context, id string) error
 	AddBannerToSlot(ctx context.Context, slotID, bannerID string) error
 	DeleteBannerFromSlot(ctx context.Context, slotID, bannerID string) error
 	AddGroup(ctx context.Context, description string) (string, error)
 	GetGroupByID(ctx context.Context, groupID string) (storage.SocialGroup, error)
+	UpdateGroup(ctx context.Context, group storage.SocialGroup) error
 	DeleteGroup(ctx context.Context, id string) error
 	PersistClick(ctx context.Context, slotID, groupID, bannerID string) error
+	PersistImpressionClick(ctx context.Context, impressionID, slotID, groupID, bannerID string) error
+	PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error
+	DeleteExpiredClickKeys(ctx context.Context, before time.Time) (int64, error)
+	DeleteExpiredImpressionClicks(ctx context.Context, before time.Time) (int64, error)
+	InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error
 	PersistShow(ctx context.Context, slotID, groupID, bannerID string) error
+	PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error
+	PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error
+	PersistStats(ctx context.Context, deltas []storage.StatsDelta) error
 	FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error)
+	FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error)
+	FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error)
+	FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error)
+	FindStatsBuckets(ctx context.Context, filter storage.StatsBucketsFilter) ([]storage.StatsBucket, error)
+	ListSlots(ctx context.Context, afterID string, limit int) ([]storage.Slot, error)
+	ListBanners(ctx context.Context, afterID string, limit int) ([]storage.Banner, error)
+	ListGroups(ctx context.Context, afterID string, limit int) ([]storage.SocialGroup, error)
+	FindSlotBanners(ctx context.Context, slotID string) ([]storage.Banner, error)
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxEvent, error)
+	MarkOutboxEventsSent(ctx context.Context, ids []string) error
+	DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error)
 }
 
 type EventsPublisher interface {
 	Publish(msg stats.Message) error
 }
 
+// ConfirmingEventsPublisher doesn't wait for the broker on publishing and reports events confirmed by the broker
+// to the handler, outbox events published through it are marked as sent only when they are confirmed.
+type ConfirmingEventsPublisher interface {
+	EventsPublisher
+	OnConfirm(handler func(eventID string))
+}
+
+type ImpressionSigner interface {
+	New(slotID, groupID, bannerID string) (impression.Impression, error)
+	Sign(imp impression.Impression) (string, error)
+	Verify(token string) (impression.Impression, error)
+	TTL() time.Duration
+}
+
 type RotationService struct {
-	repo      Repository
-	publisher EventsPublisher
+	repo           Repository
+	publisher      EventsPublisher
+	strategy       Strategy
+	defaults       config.RotationConfig
+	slotStrategies *strategyCache
+	impressions    ImpressionSigner
+	clicks         config.ClicksConfig
+	outbox         config.OutboxConfig
+	// confirmed is nil if publisher doesn't confirm events, then relayed events are marked as sent on publishing.
+	confirmed *confirmedEvents
 }
 
-func NewRotationService(repo Repository, publisher EventsPublisher) RotationService {
-	return RotationService{repo, publisher}
+// NewRotationService creates rotation service which uses strategy for slots without own settings,
+// for slots with own settings strategy is created from defaults overridden by slot settings.
+// Stats events are stored by repository with stats and published by the outbox relay (see RunOutboxRelay),
+// if publisher is ConfirmingEventsPublisher, its confirm handler is set.
+func NewRotationService(
+	repo Repository,
+	publisher EventsPublisher,
+	strategy Strategy,
+	defaults config.RotationConfig,
+	impressions ImpressionSigner,
+	clicks config.ClicksConfig,
+	outbox config.OutboxConfig,
+) RotationService {
+	r := RotationService{
+		repo:           repo,
+		publisher:      publisher,
+		strategy:       strategy,
+		defaults:       defaults,
+		slotStrategies: newStrategyCache(),
+		impressions:    impressions,
+		clicks:         clicks,
+		outbox:         outbox,
+	}
+	if confirming, ok := publisher.(ConfirmingEventsPublisher); ok {
+		r.confirmed = newConfirmedEvents(outbox.BatchSize * maxConfirmedBatches)
+		confirming.OnConfirm(func(eventID string) {
+			r.confirmed.add(eventID)
+		})
+	}
+	return r
 }
 
 func (r RotationService) AddSlot(ctx context.Context, description string) (storage.Slot, error) {
@@ -58,9 +141,101 @@ func (r RotationService) DeleteSlot(ctx context.Context, slotID string) error {
 	if err := r.repo.DeleteSlot(ctx, slotID); err != nil {
 		return fmt.Errorf("error during slot deleting: %w", err)
 	}
+	r.slotStrategies.delete(slotID)
 	return nil
 }
 
+func (r RotationService) GetSlot(ctx context.Context, slotID string) (storage.Slot, error) {
+	slot, err := r.repo.GetSlotByID(ctx, slotID)
+	if err != nil {
+		return storage.Slot{}, fmt.Errorf("error during getting slot: %w", err)
+	}
+	return slot, nil
+}
+
+func (r RotationService) UpdateSlot(ctx context.Context, slot storage.Slot) (storage.Slot, error) {
+	if err := r.repo.UpdateSlot(ctx, slot); err != nil {
+		return storage.Slot{}, fmt.Errorf("error during updating slot: %w", err)
+	}
+	return slot, nil
+}
+
+// ListSlots returns page of slots ordered by id and token of the next page, which is empty for the last page.
+//
+//nolint:lll
+func (r RotationService) ListSlots(ctx context.Context, pageToken string, pageSize int) ([]storage.Slot, string, error) {
+	afterID, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := pageLimit(pageSize)
+	slots, err := r.repo.ListSlots(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("error during listing slots: %w", err)
+	}
+	if len(slots) <= limit {
+		return slots, "", nil
+	}
+	slots = slots[:limit]
+	return slots, encodePageToken(slots[limit-1].ID), nil
+}
+
+// ListSlotBanners returns banners which rotate in the slot.
+func (r RotationService) ListSlotBanners(ctx context.Context, slotID string) ([]storage.Banner, error) {
+	if _, err := r.repo.GetSlotByID(ctx, slotID); err != nil {
+		return nil, fmt.Errorf("error during slot search: %w", err)
+	}
+	banners, err := r.repo.FindSlotBanners(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("error during listing slot banners: %w", err)
+	}
+	return banners, nil
+}
+
+func (r RotationService) GetSlotSettings(ctx context.Context, slotID string) (storage.SlotSettings, error) {
+	settings, err := r.repo.GetSlotSettings(ctx, slotID)
+	if err != nil {
+		return storage.SlotSettings{}, fmt.Errorf("error during getting slot settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SetSlotSettings validates and stores slot specific rotation settings,
+// strategy created for the previous settings of the slot is replaced.
+func (r RotationService) SetSlotSettings(ctx context.Context, settings storage.SlotSettings) error {
+	var (
+		cnf      config.RotationConfig
+		strategy Strategy
+	)
+	if !settings.IsEmpty() {
+		cnf = ApplySlotSettings(r.defaults, settings)
+		var err error
+		if strategy, err = NewStrategy(cnf); err != nil {
+			return fmt.Errorf("invalid slot settings: %w", err)
+		}
+	}
+	if err := r.repo.SetSlotSettings(ctx, settings); err != nil {
+		return fmt.Errorf("error during setting slot settings: %w", err)
+	}
+	if strategy == nil {
+		r.slotStrategies.delete(settings.SlotID)
+	} else {
+		r.slotStrategies.put(settings.SlotID, cnf, strategy)
+	}
+	return nil
+}
+
+// ApplyCatalogChange drops strategies of the slots affected by the catalog change made by any service instance,
+// so strategies of the slots with changed banners start over.
+func (r RotationService) ApplyCatalogChange(change storage.CatalogChange) {
+	switch {
+	case change.SlotID != "":
+		r.slotStrategies.delete(change.SlotID)
+	case change.GroupID == "":
+		r.slotStrategies.reset()
+	}
+}
+
 func (r RotationService) AddBannerToSlot(ctx context.Context, slotID, bannerID string) error {
 	err := r.repo.AddBannerToSlot(ctx, slotID, bannerID)
 	if err != nil {
@@ -87,6 +262,41 @@ func (r RotationService) AddBanner(ctx context.Context, description string) (sto
 	}, nil
 }
 
+func (r RotationService) GetBanner(ctx context.Context, bannerID string) (storage.Banner, error) {
+	banner, err := r.repo.GetBannerByID(ctx, bannerID)
+	if err != nil {
+		return storage.Banner{}, fmt.Errorf("error during getting banner: %w", err)
+	}
+	return banner, nil
+}
+
+func (r RotationService) UpdateBanner(ctx context.Context, banner storage.Banner) (storage.Banner, error) {
+	if err := r.repo.UpdateBanner(ctx, banner); err != nil {
+		return storage.Banner{}, fmt.Errorf("error during updating banner: %w", err)
+	}
+	return banner, nil
+}
+
+// ListBanners returns page of banners ordered by id and token of the next page, which is empty for the last page.
+//
+//nolint:lll
+func (r RotationService) ListBanners(ctx context.Context, pageToken string, pageSize int) ([]storage.Banner, string, error) {
+	afterID, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := pageLimit(pageSize)
+	banners, err := r.repo.ListBanners(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("error during listing banners: %w", err)
+	}
+	if len(banners) <= limit {
+		return banners, "", nil
+	}
+	banners = banners[:limit]
+	return banners, encodePageToken(banners[limit-1].ID), nil
+}
+
 func (r RotationService) DeleteBanner(ctx context.Context, bannerID string) error {
 	if err := r.repo.DeleteBanner(ctx, bannerID); err != nil {
 		return fmt.Errorf("error during deleting banner: %w", err)
@@ -105,6 +315,41 @@ func (r RotationService) AddGroup(ctx context.Context, description string) (stor
 	}, nil
 }
 
+func (r RotationService) GetGroup(ctx context.Context, groupID string) (storage.SocialGroup, error) {
+	group, err := r.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return storage.SocialGroup{}, fmt.Errorf("error during getting group: %w", err)
+	}
+	return group, nil
+}
+
+func (r RotationService) UpdateGroup(ctx context.Context, group storage.SocialGroup) (storage.SocialGroup, error) {
+	if err := r.repo.UpdateGroup(ctx, group); err != nil {
+		return storage.SocialGroup{}, fmt.Errorf("error during updating group: %w", err)
+	}
+	return group, nil
+}
+
+// ListGroups returns page of groups ordered by id and token of the next page, which is empty for the last page.
+//
+//nolint:lll
+func (r RotationService) ListGroups(ctx context.Context, pageToken string, pageSize int) ([]storage.SocialGroup, string, error) {
+	afterID, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := pageLimit(pageSize)
+	groups, err := r.repo.ListGroups(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("error during listing groups: %w", err)
+	}
+	if len(groups) <= limit {
+		return groups, "", nil
+	}
+	groups = groups[:limit]
+	return groups, encodePageToken(groups[limit-1].ID), nil
+}
+
 func (r RotationService) DeleteGroup(ctx context.Context, groupID string) error {
 	if err := r.repo.DeleteGroup(ctx, groupID); err != nil {
 		return fmt.Errorf("error during deleting group by id: %w", err)
@@ -116,84 +361,505 @@ func (r RotationService) PersistClick(ctx context.Context, slotID, groupID, bann
 	if err := r.repo.PersistClick(ctx, slotID, groupID, bannerID); err != nil {
 		return fmt.Errorf("failed to persist banner click stats: %w", err)
 	}
-	if err := r.publisher.Publish(stats.Message{
-		BannerID:  bannerID,
-		SlotID:    slotID,
-		GroupID:   groupID,
-		Type:      "click",
-		Timestamp: time.Now(),
-	}); err != nil {
-		return fmt.Errorf("failed to publish click event stats to rabbit queue: %w", err)
+	return nil
+}
+
+// PersistClickOnce counts click sent with the idempotency key.
+// Repeated clicks with the same key during the dedup window are acknowledged but not counted,
+// for such clicks true is returned.
+//
+//nolint:lll
+func (r RotationService) PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string) (bool, error) {
+	err := r.repo.PersistClickOnce(ctx, idempotencyKey, slotID, groupID, bannerID, r.clicks.DedupWindow)
+	switch {
+	case errors.Is(err, storage.ErrDuplicateClick):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to persist banner click stats: %w", err)
+	}
+	return false, nil
+}
+
+// CleanupClickKeys removes idempotency keys which are older than the dedup window.
+func (r RotationService) CleanupClickKeys(ctx context.Context) (int64, error) {
+	deleted, err := r.repo.DeleteExpiredClickKeys(ctx, time.Now().Add(-r.clicks.DedupWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired click idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
+
+// CleanupImpressionClicks removes impression clicks which are older than the impression token TTL,
+// tokens of such impressions are expired, so their clicks can't be counted again anyway.
+// Nothing is removed if tokens never expire.
+func (r RotationService) CleanupImpressionClicks(ctx context.Context) (int64, error) {
+	ttl := r.impressions.TTL()
+	if ttl <= 0 {
+		return 0, nil
+	}
+	deleted, err := r.repo.DeleteExpiredImpressionClicks(ctx, time.Now().Add(-ttl))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired impression clicks: %w", err)
+	}
+	return deleted, nil
+}
+
+// RunClicksCleanup periodically removes expired idempotency keys and impression clicks until context is done.
+func (r RotationService) RunClicksCleanup(ctx context.Context) {
+	ticker := time.NewTicker(r.clicks.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if deleted, err := r.CleanupClickKeys(ctx); err != nil {
+				zap.L().Error("click idempotency keys cleanup failed", zap.Error(err))
+			} else {
+				zap.L().Debug("expired click idempotency keys removed", zap.Int64("deleted", deleted))
+			}
+			if deleted, err := r.CleanupImpressionClicks(ctx); err != nil {
+				zap.L().Error("impression clicks cleanup failed", zap.Error(err))
+			} else {
+				zap.L().Debug("expired impression clicks removed", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
+
+// RelayOutboxEvents publishes a batch of not sent outbox events, amount of published events is returned.
+// Published events are marked as sent at once if publisher doesn't confirm events,
+// otherwise they are marked when confirmed (see MarkConfirmedOutboxEvents). Publishing stops on the first failure,
+// not sent events of the batch are published again after the lease end.
+func (r RotationService) RelayOutboxEvents(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimOutboxEvents(ctx, r.outbox.BatchSize, r.outbox.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	sent := make([]string, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if err := r.publisher.Publish(stats.Message{
+			ID:        event.ID,
+			BannerID:  event.BannerID,
+			SlotID:    event.SlotID,
+			GroupID:   event.GroupID,
+			Type:      event.Type,
+			Timestamp: event.CreatedAt,
+		}); err != nil {
+			publishErr = fmt.Errorf("failed to publish %s event stats to rabbit queue: %w", event.Type, err)
+			break
+		}
+		sent = append(sent, event.ID)
+	}
+	if len(sent) > 0 && r.confirmed == nil {
+		if err := r.repo.MarkOutboxEventsSent(ctx, sent); err != nil {
+			return 0, fmt.Errorf("failed to mark outbox events as sent: %w", err)
+		}
+	}
+	return len(sent), publishErr
+}
+
+// MarkConfirmedOutboxEvents marks as sent outbox events confirmed by the broker since the previous call,
+// amount of marked events is returned. Events which failed to be marked are marked on the next call,
+// events confirmed but not marked before stop are published again after the lease end.
+// Only ids of the last confirmed events are kept while marking fails.
+func (r RotationService) MarkConfirmedOutboxEvents(ctx context.Context) (int, error) {
+	if r.confirmed == nil {
+		return 0, nil
+	}
+	ids := r.confirmed.take()
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := r.repo.MarkOutboxEventsSent(ctx, ids); err != nil {
+		r.confirmed.add(ids...)
+		return 0, fmt.Errorf("failed to mark confirmed outbox events as sent: %w", err)
+	}
+	return len(ids), nil
+}
+
+// CleanupOutbox removes outbox events which were sent before the retention.
+func (r RotationService) CleanupOutbox(ctx context.Context) (int64, error) {
+	deleted, err := r.repo.DeleteSentOutboxEvents(ctx, time.Now().Add(-r.outbox.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sent outbox events: %w", err)
+	}
+	return deleted, nil
+}
+
+// RunOutboxRelay publishes outbox events every poll interval and removes sent events every cleanup interval
+// until context is done. On each poll events are relayed in batches until outbox is drained.
+// Events confirmed by the broker are marked as sent every poll interval independently of publishing.
+func (r RotationService) RunOutboxRelay(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	if r.confirmed != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runConfirmedOutboxMarking(ctx)
+		}()
+	}
+
+	poll := time.NewTicker(r.outbox.PollInterval)
+	defer poll.Stop()
+	cleanup := time.NewTicker(r.outbox.CleanupInterval)
+	defer cleanup.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			r.drainOutbox(ctx)
+		case <-cleanup.C:
+			deleted, err := r.CleanupOutbox(ctx)
+			if err != nil {
+				zap.L().Error("outbox cleanup failed", zap.Error(err))
+				continue
+			}
+			zap.L().Debug("sent outbox events removed", zap.Int64("deleted", deleted))
+		}
+	}
+}
+
+func (r RotationService) runConfirmedOutboxMarking(ctx context.Context) {
+	ticker := time.NewTicker(r.outbox.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if marked, err := r.MarkConfirmedOutboxEvents(ctx); err != nil {
+				zap.L().Error("confirmed outbox events marking failed", zap.Error(err))
+			} else if marked > 0 {
+				zap.L().Debug("confirmed outbox events marked as sent", zap.Int("marked", marked))
+			}
+		}
+	}
+}
+
+func (r RotationService) drainOutbox(ctx context.Context) {
+	for ctx.Err() == nil {
+		sent, err := r.RelayOutboxEvents(ctx)
+		if err != nil {
+			zap.L().Error("outbox events relay failed", zap.Int("sent", sent), zap.Error(err))
+			return
+		}
+		if sent < r.outbox.BatchSize {
+			return
+		}
+	}
+}
+
+// IssueImpressionToken returns signed token of the banner show,
+// token should be passed back to PersistImpressionClick when user clicks the banner.
+func (r RotationService) IssueImpressionToken(slotID, groupID, bannerID string) (string, error) {
+	imp, err := r.impressions.New(slotID, groupID, bannerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create impression: %w", err)
+	}
+	token, err := r.impressions.Sign(imp)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign impression: %w", err)
+	}
+	return token, nil
+}
+
+// PersistImpressionClick counts click of the impression from the signed token.
+// Only the first click of each impression is counted.
+func (r RotationService) PersistImpressionClick(ctx context.Context, token string) error {
+	imp, err := r.impressions.Verify(token)
+	if err != nil {
+		return fmt.Errorf("failed to verify impression token: %w", err)
+	}
+	if err := r.repo.PersistImpressionClick(ctx, imp.ID, imp.SlotID, imp.GroupID, imp.BannerID); err != nil {
+		return fmt.Errorf("failed to persist impression click stats: %w", err)
 	}
 	return nil
 }
 
-// NextBannerID function returns id of a banner which should be shown next
-// Implementation function is based on UCB1 algo for a multiarmed bandit problem
-// Al the theory behind the scenes can be found in paper below:
-// DOI:10.1023/A:1013689704352, Authors: Auer et al., 2002.
-// Original link: https://link.springer.com/content/pdf/10.1023/A:1013689704352.pdf.
-func (r RotationService) NextBannerID(ctx context.Context, slotID, groupID string) (string, error) {
-	bannerStats, err := r.repo.FindSlotBannerStats(ctx, slotID, groupID)
+// NextBannerID function returns id of a banner which should be shown next and the signed token of its impression.
+// Banner is chosen by the slot Strategy (service default one if slot has no own settings).
+//
+//nolint:lll
+func (r RotationService) NextBannerID(ctx context.Context, slotID, groupID string) (bannerID, token string, err error) {
+	strategy, err := r.slotStrategy(ctx, slotID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get banner statistics for a slot: %w", err)
+		return "", "", err
 	}
-	if len(bannerStats) == 0 {
-		return "", storage.ErrNoBannersFoundForSlot
+	// stats reading and show storing are done in one unit of work,
+	// so concurrent requests don't choose banner by the same stats
+	if err := r.repo.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		bannerStats, err := findBannerStats(ctx, tx, strategy, slotID, groupID)
+		if err != nil {
+			return fmt.Errorf("failed to get banner statistics for a slot: %w", err)
+		}
+		if len(bannerStats) == 0 {
+			return storage.ErrNoBannersFoundForSlot
+		}
+
+		bannerID = strategy.NextBannerID(ctx, bannerStats)
+		// token is issued before the show is stored, so show isn't stored if token can't be issued
+		if token, err = r.IssueImpressionToken(slotID, groupID, bannerID); err != nil {
+			return err
+		}
+		if err := tx.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+			return fmt.Errorf("failed to store banner show: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return "", "", err
 	}
 
-	maxBannerID := calculateNextBannerID(bannerStats)
-	if err := r.repo.PersistShow(ctx, slotID, groupID, maxBannerID); err != nil {
-		return "", fmt.Errorf("failed to store banner show: %w", err)
+	return bannerID, token, nil
+}
+
+// NextBannerIDs function returns up to count distinct banner ids which should be shown next in the slot
+// (e.g. in a carousel) ranked by the slot Strategy and the signed tokens of their impressions.
+// Shows of all returned banners are stored at once.
+//
+//nolint:lll
+func (r RotationService) NextBannerIDs(ctx context.Context, slotID, groupID string, count int) (bannerIDs, tokens []string, err error) {
+	strategy, err := r.slotStrategy(ctx, slotID)
+	if err != nil {
+		return nil, nil, err
 	}
-	if err := r.publisher.Publish(stats.Message{
-		BannerID:  maxBannerID,
-		SlotID:    slotID,
-		GroupID:   groupID,
-		Type:      "show",
-		Timestamp: time.Now(),
+	if err := r.repo.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		bannerStats, err := findBannerStats(ctx, tx, strategy, slotID, groupID)
+		if err != nil {
+			return fmt.Errorf("failed to get banner statistics for a slot: %w", err)
+		}
+		if len(bannerStats) == 0 {
+			return storage.ErrNoBannersFoundForSlot
+		}
+
+		bannerIDs = RankBanners(ctx, strategy, bannerStats, count)
+		tokens = make([]string, 0, len(bannerIDs))
+		for _, bannerID := range bannerIDs {
+			token, err := r.IssueImpressionToken(slotID, groupID, bannerID)
+			if err != nil {
+				return err
+			}
+			tokens = append(tokens, token)
+		}
+		if err := tx.PersistShows(ctx, slotID, groupID, bannerIDs); err != nil {
+			return fmt.Errorf("failed to store banner shows: %w", err)
+		}
+		return nil
 	}); err != nil {
-		return "", fmt.Errorf("failed to publish click event stats to rabbit queue: %w", err)
+		return nil, nil, err
+	}
+	return bannerIDs, tokens, nil
+}
+
+// NextBannerIDsForSlots function returns a banner which should be shown next for each of the slots
+// (e.g. for all slots of a page) and the signed tokens of their impressions, result is in the same order as slotIDs.
+// If slot has no banners, empty banner id and token are returned for it.
+// Stats of all slots are read at once (one query per distinct strategy stats window)
+// and all shows are stored at once in one unit of work.
+//
+//nolint:lll
+func (r RotationService) NextBannerIDsForSlots(ctx context.Context, slotIDs []string, groupID string) ([]storage.SlotBanner, []string, error) {
+	if r.defaults.MaxBatchSlots > 0 && len(slotIDs) > r.defaults.MaxBatchSlots {
+		return nil, nil, fmt.Errorf("%w: %d slots requested, max is %d", ErrTooManySlots, len(slotIDs), r.defaults.MaxBatchSlots)
+	}
+	slotsSettings, err := r.repo.GetSlotsSettings(ctx, slotIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get slots rotation settings: %w", err)
+	}
+	strategies := make(map[string]Strategy, len(slotsSettings))
+	windows := make(map[time.Duration][]string)
+	for _, settings := range slotsSettings {
+		strategy, err := r.strategyForSettings(settings)
+		if err != nil {
+			return nil, nil, err
+		}
+		strategies[settings.SlotID] = strategy
+		var window time.Duration
+		if windowed, ok := strategy.(WindowedStrategy); ok {
+			window = windowed.Window()
+		}
+		windows[window] = append(windows[window], settings.SlotID)
 	}
+	for _, slotID := range slotIDs {
+		if _, ok := strategies[slotID]; !ok {
+			return nil, nil, fmt.Errorf("slot %s: %w", slotID, storage.ErrSlotNotFound)
+		}
+	}
+
+	var (
+		result []storage.SlotBanner
+		tokens []string
+	)
+	if err := r.repo.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		slotsStats, err := findSlotsBannerStats(ctx, tx, windows, groupID)
+		if err != nil {
+			return fmt.Errorf("failed to get banner statistics for slots: %w", err)
+		}
+
+		result = make([]storage.SlotBanner, 0, len(slotIDs))
+		tokens = make([]string, 0, len(slotIDs))
+		shows := make([]storage.SlotBanner, 0, len(slotIDs))
+		for _, slotID := range slotIDs {
+			bannerStats := slotsStats[slotID]
+			if len(bannerStats) == 0 {
+				result = append(result, storage.SlotBanner{SlotID: slotID})
+				tokens = append(tokens, "")
+				continue
+			}
+			show := storage.SlotBanner{
+				SlotID:   slotID,
+				BannerID: strategies[slotID].NextBannerID(ctx, bannerStats),
+			}
+			token, err := r.IssueImpressionToken(slotID, groupID, show.BannerID)
+			if err != nil {
+				return err
+			}
+			result = append(result, show)
+			tokens = append(tokens, token)
+			shows = append(shows, show)
+		}
 
-	return maxBannerID, nil
+		if len(shows) == 0 {
+			return nil
+		}
+		if err := tx.PersistSlotsShows(ctx, groupID, shows); err != nil {
+			return fmt.Errorf("failed to store banner shows: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return result, tokens, nil
 }
 
-// calculateNextBannerId - evaluates (using UCB1 algo) next banner id using banner shows and clicks stats.
-func calculateNextBannerID(bannerStats []storage.SlotBannerStat) string {
-	// all available banners should be shown at least once
-	for _, bannerStat := range bannerStats {
-		if bannerStat.GetShows() == 0 {
-			return bannerStat.BannerID
+// GetSlotStats returns shows and clicks of the current slot banners per group summed from the hour buckets
+// in [from, to) range, zero from or to means no bound. Stats collected before the buckets were introduced
+// are stored in the epoch bucket, so stats without bounds are the lifetime ones.
+// If group is provided, all slot banners are returned, banners without stats in the range have no amounts.
+//
+//nolint:lll
+func (r RotationService) GetSlotStats(ctx context.Context, slotID, groupID string, from, to time.Time) ([]storage.SlotBannerStat, error) {
+	if _, err := r.repo.GetSlotByID(ctx, slotID); err != nil {
+		return nil, fmt.Errorf("error during slot search: %w", err)
+	}
+	banners, err := r.repo.FindSlotBanners(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("error during slot banners search: %w", err)
+	}
+
+	buckets, err := r.repo.FindStatsBuckets(ctx, storage.StatsBucketsFilter{
+		SlotID:  slotID,
+		GroupID: groupID,
+		From:    from,
+		To:      to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during slot stats buckets search: %w", err)
+	}
+	type statKey struct {
+		bannerID string
+		groupID  string
+	}
+	indexes := make(map[statKey]int)
+	var stats []storage.SlotBannerStat
+	slotBanners := make(map[string]struct{}, len(banners))
+	for _, banner := range banners {
+		slotBanners[banner.ID] = struct{}{}
+		if groupID != "" {
+			indexes[statKey{bannerID: banner.ID, groupID: groupID}] = len(stats)
+			stats = append(stats, storage.SlotBannerStat{SlotID: slotID, GroupID: groupID, BannerID: banner.ID})
 		}
 	}
+	for _, bucket := range buckets {
+		// stats of banners removed from the slot are kept, but they are not slot stats anymore
+		if _, ok := slotBanners[bucket.BannerID]; !ok {
+			continue
+		}
+		key := statKey{bannerID: bucket.BannerID, groupID: bucket.GroupID}
+		i, ok := indexes[key]
+		if !ok {
+			i = len(stats)
+			indexes[key] = i
+			stats = append(stats, storage.SlotBannerStat{SlotID: slotID, GroupID: bucket.GroupID, BannerID: bucket.BannerID})
+		}
+		stats[i].ClickAmount = sql.NullInt64{Int64: stats[i].GetClicks() + bucket.Clicks, Valid: true}
+		stats[i].ShowAmount = sql.NullInt64{Int64: stats[i].GetShows() + bucket.Shows, Valid: true}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].BannerID != stats[j].BannerID {
+			return stats[i].BannerID < stats[j].BannerID
+		}
+		return stats[i].GroupID < stats[j].GroupID
+	})
+	return stats, nil
+}
+
+// findSlotsBannerStats returns banner stats of slots grouped by strategy stats window,
+// zero window means lifetime stats. Windows are read in ascending order and slots of each window
+// are locked in id order, so concurrent units of work lock slots in the same order.
+//
+//nolint:lll
+func findSlotsBannerStats(ctx context.Context, tx storage.StatsTx, windows map[time.Duration][]string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	sortedWindows := make([]time.Duration, 0, len(windows))
+	for window := range windows {
+		sortedWindows = append(sortedWindows, window)
+	}
+	sort.Slice(sortedWindows, func(i, j int) bool { return sortedWindows[i] < sortedWindows[j] })
 
-	// show banner which has max targetFunction value
-	totalBannerShows := countTotalShowsAmount(bannerStats)
-	maxTargetValue := 0.0
-	maxBannerID := bannerStats[0].BannerID
-	for _, bannerStat := range bannerStats {
-		bannerClicks, bannerShows := bannerStat.GetClicks(), bannerStat.GetShows()
-		targetValue := targetFunction(float64(bannerClicks), float64(bannerShows), float64(totalBannerShows))
-		if big.NewFloat(targetValue).Cmp(big.NewFloat(maxTargetValue)) > 0 {
-			maxTargetValue = targetValue
-			maxBannerID = bannerStat.BannerID
+	slotsStats := make(map[string][]storage.SlotBannerStat)
+	for _, window := range sortedWindows {
+		var (
+			windowStats map[string][]storage.SlotBannerStat
+			err         error
+		)
+		if window == 0 {
+			windowStats, err = tx.FindSlotsBannerStats(ctx, windows[window], groupID)
+		} else {
+			windowStats, err = tx.FindSlotsBannerStatsSince(ctx, windows[window], groupID, time.Now().Add(-window))
+		}
+		if err != nil {
+			return nil, err
 		}
+		for slotID, bannerStats := range windowStats {
+			slotsStats[slotID] = bannerStats
+		}
+	}
+	return slotsStats, nil
+}
+
+// slotStrategy returns strategy which should be used to choose banners for the slot.
+func (r RotationService) slotStrategy(ctx context.Context, slotID string) (Strategy, error) {
+	settings, err := r.repo.GetSlotSettings(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slot rotation settings: %w", err)
 	}
-	return maxBannerID
+	return r.strategyForSettings(settings)
 }
 
-func countTotalShowsAmount(stats []storage.SlotBannerStat) int64 {
-	var totalShows int64
-	for _, stat := range stats {
-		totalShows += stat.GetShows()
+// strategyForSettings returns strategy for slot settings, default strategy is used for empty settings.
+func (r RotationService) strategyForSettings(settings storage.SlotSettings) (Strategy, error) {
+	if settings.IsEmpty() {
+		r.slotStrategies.delete(settings.SlotID)
+		return r.strategy, nil
+	}
+	strategy, err := r.slotStrategies.get(settings.SlotID, ApplySlotSettings(r.defaults, settings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slot rotation strategy: %w", err)
 	}
-	return totalShows
+	return strategy, nil
 }
 
-// targetFunction is a maximizing on each step in UCB1 algo function value
-// it should be used to evaluate value for each banner.
-func targetFunction(clickCount, showCount, totalShowCount float64) float64 {
-	avgBannerIncome := clickCount / showCount
-	return avgBannerIncome + math.Sqrt((2.0*math.Log(totalShowCount))/showCount)
+// findBannerStats returns lifetime banner stats or, if strategy is a WindowedStrategy,
+// only stats collected during the strategy time window.
+//
+//nolint:lll
+func findBannerStats(ctx context.Context, tx storage.StatsTx, strategy Strategy, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	windowed, ok := strategy.(WindowedStrategy)
+	if !ok {
+		return tx.FindSlotBannerStats(ctx, slotID, groupID)
+	}
+	return tx.FindSlotBannerStatsSince(ctx, slotID, groupID, time.Now().Add(-windowed.Window()))
 }
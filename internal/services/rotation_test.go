@@ -3,14 +3,21 @@ package services_test
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/impression"
 	"github.com/Raschudesny/otus_project/v1/internal/services"
 	"github.com/Raschudesny/otus_project/v1/internal/stats"
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/memory"
 	"github.com/bxcodec/faker/v3"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +30,7 @@ type RotationSuite struct {
 	ctl             *gomock.Controller
 	mockRepo        *MockRepository
 	mockPublisher   *MockEventsPublisher
+	impressions     *impression.Signer
 	rotationService services.RotationService
 }
 
@@ -36,7 +44,18 @@ func (s *RotationSuite) SetupTest() {
 	s.ctl = gomock.NewController(s.T())
 	s.mockRepo = NewMockRepository(s.ctl)
 	s.mockPublisher = NewMockEventsPublisher(s.ctl)
-	s.rotationService = services.NewRotationService(s.mockRepo, s.mockPublisher)
+	impressions, err := impression.NewSigner(config.ImpressionConfig{Secret: "test secret", TTL: time.Hour})
+	s.Require().NoError(err)
+	s.impressions = impressions
+	s.rotationService = services.NewRotationService(
+		s.mockRepo,
+		s.mockPublisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{DedupWindow: time.Minute},
+		config.OutboxConfig{BatchSize: 100, Lease: time.Minute, Retention: time.Hour},
+	)
 
 	// init random function
 	seed := time.Now().UnixNano()
@@ -47,6 +66,14 @@ func (s *RotationSuite) SetupTest() {
 func (s *RotationSuite) TearDownTest() {
 }
 
+// expectStatsTx expects units of work which are executed over the mock repository.
+func (s RotationSuite) expectStatsTx(times int) {
+	s.mockRepo.EXPECT().InStatsTx(s.ctx, gomock.Any()).Times(times).DoAndReturn(
+		func(_ context.Context, fn func(tx storage.StatsTx) error) error {
+			return fn(s.mockRepo)
+		})
+}
+
 func (s RotationSuite) TestAddBanner() {
 	testBanner, err := fakeBanner()
 	s.Require().NoError(err)
@@ -130,18 +157,297 @@ func (s RotationSuite) TestPersistClick() {
 	testGroupID := faker.UUIDHyphenated()
 
 	s.mockRepo.EXPECT().PersistClick(s.ctx, testSlotID, testGroupID, testBannerID).Times(1).Return(nil)
-	s.mockPublisher.EXPECT().Publish(matchWithBannerID(messageMatcher{stats.Message{
-		BannerID:  testBannerID,
-		SlotID:    testSlotID,
-		GroupID:   testGroupID,
-		Type:      "click",
-		Timestamp: time.Now(),
-	}})).Times(1).Return(nil)
 
 	err := s.rotationService.PersistClick(s.ctx, testSlotID, testGroupID, testBannerID)
 	s.Require().NoError(err)
 }
 
+func (s RotationSuite) TestPersistClickOnce() {
+	testSlotID := faker.UUIDHyphenated()
+	testBannerID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	testKey := faker.UUIDHyphenated()
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().
+			PersistClickOnce(s.ctx, testKey, testSlotID, testGroupID, testBannerID, time.Minute).
+			Return(nil),
+		s.mockRepo.EXPECT().
+			PersistClickOnce(s.ctx, testKey, testSlotID, testGroupID, testBannerID, time.Minute).
+			Return(storage.ErrDuplicateClick),
+	)
+
+	duplicate, err := s.rotationService.PersistClickOnce(s.ctx, testKey, testSlotID, testGroupID, testBannerID)
+	s.Require().NoError(err)
+	s.Require().False(duplicate)
+
+	duplicate, err = s.rotationService.PersistClickOnce(s.ctx, testKey, testSlotID, testGroupID, testBannerID)
+	s.Require().NoError(err)
+	s.Require().True(duplicate)
+}
+
+func (s RotationSuite) TestPersistClickOnceNotShown() {
+	testKey := faker.UUIDHyphenated()
+	s.mockRepo.EXPECT().
+		PersistClickOnce(s.ctx, testKey, gomock.Any(), gomock.Any(), gomock.Any(), time.Minute).
+		Return(storage.ErrBannerNotShown)
+
+	_, err := s.rotationService.PersistClickOnce(s.ctx, testKey, "slot", "group", "banner")
+	s.Require().True(errors.Is(err, storage.ErrBannerNotShown))
+}
+
+func (s RotationSuite) TestCleanupClickKeys() {
+	s.mockRepo.EXPECT().DeleteExpiredClickKeys(s.ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, before time.Time) (int64, error) {
+			s.Require().WithinDuration(time.Now().Add(-time.Minute), before, time.Second)
+			return 3, nil
+		})
+
+	deleted, err := s.rotationService.CleanupClickKeys(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(3), deleted)
+}
+
+func (s RotationSuite) TestCleanupImpressionClicks() {
+	s.mockRepo.EXPECT().DeleteExpiredImpressionClicks(s.ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, before time.Time) (int64, error) {
+			s.Require().WithinDuration(time.Now().Add(-time.Hour), before, time.Second)
+			return 2, nil
+		})
+
+	deleted, err := s.rotationService.CleanupImpressionClicks(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), deleted)
+}
+
+func fakeOutboxEvents(count int) []storage.OutboxEvent {
+	events := make([]storage.OutboxEvent, 0, count)
+	for i := 0; i < count; i++ {
+		events = append(events, storage.OutboxEvent{
+			ID:        faker.UUIDHyphenated(),
+			Type:      storage.EventTypeShow,
+			SlotID:    faker.UUIDHyphenated(),
+			GroupID:   faker.UUIDHyphenated(),
+			BannerID:  faker.UUIDHyphenated(),
+			CreatedAt: time.Now().Add(-time.Duration(count-i) * time.Second),
+		})
+	}
+	return events
+}
+
+func outboxEventMessage(event storage.OutboxEvent) stats.Message {
+	return stats.Message{
+		ID:        event.ID,
+		BannerID:  event.BannerID,
+		SlotID:    event.SlotID,
+		GroupID:   event.GroupID,
+		Type:      event.Type,
+		Timestamp: event.CreatedAt,
+	}
+}
+
+func (s RotationSuite) TestRelayOutboxEvents() {
+	events := fakeOutboxEvents(2)
+
+	s.mockRepo.EXPECT().ClaimOutboxEvents(s.ctx, 100, time.Minute).Return(events, nil)
+	gomock.InOrder(
+		s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[0])).Return(nil),
+		s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[1])).Return(nil),
+		s.mockRepo.EXPECT().MarkOutboxEventsSent(s.ctx, []string{events[0].ID, events[1].ID}).Return(nil),
+	)
+
+	sent, err := s.rotationService.RelayOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(2, sent)
+}
+
+func (s RotationSuite) TestRelayOutboxEventsPublishFailure() {
+	events := fakeOutboxEvents(3)
+
+	s.mockRepo.EXPECT().ClaimOutboxEvents(s.ctx, 100, time.Minute).Return(events, nil)
+	gomock.InOrder(
+		s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[0])).Return(nil),
+		s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[1])).Return(stats.ErrNotConnected),
+		s.mockRepo.EXPECT().MarkOutboxEventsSent(s.ctx, []string{events[0].ID}).Return(nil),
+	)
+
+	sent, err := s.rotationService.RelayOutboxEvents(s.ctx)
+	s.Require().True(errors.Is(err, stats.ErrNotConnected))
+	s.Require().Equal(1, sent)
+}
+
+// confirmingPublisher publishes events to the mocked publisher and confirms them on demand.
+type confirmingPublisher struct {
+	services.EventsPublisher
+	confirm func(eventID string)
+}
+
+func (p *confirmingPublisher) OnConfirm(handler func(eventID string)) {
+	p.confirm = handler
+}
+
+func (s RotationSuite) TestRelayOutboxEventsMarkedOnConfirm() {
+	publisher := &confirmingPublisher{EventsPublisher: s.mockPublisher}
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		publisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{},
+		config.OutboxConfig{BatchSize: 100, Lease: time.Minute, Retention: time.Hour},
+	)
+	events := fakeOutboxEvents(2)
+
+	// published events aren't marked as sent until they are confirmed
+	s.mockRepo.EXPECT().ClaimOutboxEvents(s.ctx, 100, time.Minute).Return(events, nil)
+	s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[0])).Return(nil)
+	s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[1])).Return(nil)
+	sent, err := rotationService.RelayOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(2, sent)
+	marked, err := rotationService.MarkConfirmedOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(marked)
+
+	// events which failed to be marked are marked on the next call
+	publisher.confirm(events[1].ID)
+	gomock.InOrder(
+		s.mockRepo.EXPECT().MarkOutboxEventsSent(s.ctx, []string{events[1].ID}).Return(sql.ErrConnDone),
+		s.mockRepo.EXPECT().MarkOutboxEventsSent(s.ctx, []string{events[1].ID, events[0].ID}).Return(nil),
+	)
+	_, err = rotationService.MarkConfirmedOutboxEvents(s.ctx)
+	s.Require().True(errors.Is(err, sql.ErrConnDone))
+	publisher.confirm(events[0].ID)
+	marked, err = rotationService.MarkConfirmedOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(2, marked)
+}
+
+func (s RotationSuite) TestConfirmedOutboxEventsBounded() {
+	publisher := &confirmingPublisher{EventsPublisher: s.mockPublisher}
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		publisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{},
+		config.OutboxConfig{BatchSize: 1, Lease: time.Minute, Retention: time.Hour},
+	)
+
+	// only ids of the last confirmed events are kept until they are marked
+	ids := make([]string, 0, 150)
+	for i := 0; i < cap(ids); i++ {
+		ids = append(ids, faker.UUIDHyphenated())
+		publisher.confirm(ids[i])
+	}
+	s.mockRepo.EXPECT().MarkOutboxEventsSent(s.ctx, ids[50:]).Return(nil)
+	marked, err := rotationService.MarkConfirmedOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(100, marked)
+}
+
+// blockingPublisher waits for the release before publishing.
+type blockingPublisher struct {
+	confirmingPublisher
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingPublisher) Publish(msg stats.Message) error {
+	close(p.started)
+	<-p.release
+	return p.confirmingPublisher.Publish(msg)
+}
+
+// TestRunOutboxRelayMarksConfirmedWhilePublishing checks that confirmed events are marked as sent
+// while the relay waits for the publisher.
+func (s RotationSuite) TestRunOutboxRelayMarksConfirmedWhilePublishing() {
+	publisher := &blockingPublisher{
+		confirmingPublisher: confirmingPublisher{EventsPublisher: s.mockPublisher},
+		started:             make(chan struct{}),
+		release:             make(chan struct{}),
+	}
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		publisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{},
+		config.OutboxConfig{
+			PollInterval:    time.Millisecond * 10,
+			BatchSize:       1,
+			Lease:           time.Minute,
+			Retention:       time.Hour,
+			CleanupInterval: time.Hour,
+		},
+	)
+	events := fakeOutboxEvents(2)
+	marked := make(chan struct{})
+	s.mockRepo.EXPECT().ClaimOutboxEvents(gomock.Any(), 1, time.Minute).Return(events[1:], nil)
+	s.mockRepo.EXPECT().ClaimOutboxEvents(gomock.Any(), 1, time.Minute).AnyTimes().Return(nil, nil)
+	s.mockPublisher.EXPECT().Publish(outboxEventMessage(events[1])).Return(nil)
+	s.mockRepo.EXPECT().MarkOutboxEventsSent(gomock.Any(), []string{events[0].ID}).DoAndReturn(
+		func(context.Context, []string) error {
+			close(marked)
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rotationService.RunOutboxRelay(ctx)
+	}()
+	<-publisher.started
+	publisher.confirm(events[0].ID)
+	select {
+	case <-marked:
+	case <-s.ctx.Done():
+		s.FailNow("confirmed event isn't marked while publishing")
+	}
+	close(publisher.release)
+	cancel()
+	<-done
+}
+
+func (s RotationSuite) TestCleanupOutbox() {
+	s.mockRepo.EXPECT().DeleteSentOutboxEvents(s.ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, before time.Time) (int64, error) {
+			s.Require().WithinDuration(time.Now().Add(-time.Hour), before, time.Second)
+			return 5, nil
+		})
+
+	deleted, err := s.rotationService.CleanupOutbox(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(5), deleted)
+}
+
+func (s RotationSuite) TestPersistImpressionClick() {
+	testSlotID := faker.UUIDHyphenated()
+	testBannerID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	token, err := s.rotationService.IssueImpressionToken(testSlotID, testGroupID, testBannerID)
+	s.Require().NoError(err)
+	imp, err := s.impressions.Verify(token)
+	s.Require().NoError(err)
+
+	s.mockRepo.EXPECT().PersistImpressionClick(s.ctx, imp.ID, testSlotID, testGroupID, testBannerID).Return(nil)
+	s.mockRepo.EXPECT().
+		PersistImpressionClick(s.ctx, imp.ID, testSlotID, testGroupID, testBannerID).
+		Return(storage.ErrImpressionAlreadyClicked)
+
+	s.Require().NoError(s.rotationService.PersistImpressionClick(s.ctx, token))
+	err = s.rotationService.PersistImpressionClick(s.ctx, token)
+	s.Require().True(errors.Is(err, storage.ErrImpressionAlreadyClicked))
+
+	err = s.rotationService.PersistImpressionClick(s.ctx, token+"x")
+	s.Require().True(errors.Is(err, impression.ErrInvalidToken))
+}
+
 // TestNextBannerIDBasic - test purpose is just to check that NextBannerID correctly works with a storage.
 func (s RotationSuite) TestNextBannerIDBasic() {
 	testStats := fakeStatsSlice()
@@ -149,19 +455,93 @@ func (s RotationSuite) TestNextBannerIDBasic() {
 	testSlotID := faker.UUIDHyphenated()
 	testGroupID := faker.UUIDHyphenated()
 
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(1).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(1)
 	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Times(1).Return(testStats, nil)
 	s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, gomock.Any()).Times(1).Return(nil)
-	// unable to determine here what banner id will be selected
-	s.mockPublisher.EXPECT().Publish(messageMatcher{stats.Message{
-		BannerID:  "any",
-		SlotID:    testSlotID,
-		GroupID:   testGroupID,
-		Type:      "show",
-		Timestamp: time.Now(),
-	}}).Times(1).Return(nil)
 
-	_, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	id, token, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
 	s.Require().NoError(err)
+	imp, err := s.impressions.Verify(token)
+	s.Require().NoError(err)
+	s.Require().Equal(id, imp.BannerID)
+}
+
+// failingSigner is an impression signer which can't sign tokens.
+type failingSigner struct {
+	services.ImpressionSigner
+}
+
+func (failingSigner) Sign(impression.Impression) (string, error) {
+	return "", errors.New("signing failed")
+}
+
+// TestNextBannerIDSigningFailure checks that show isn't stored if impression token can't be issued.
+func (s RotationSuite) TestNextBannerIDSigningFailure() {
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		s.mockPublisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		failingSigner{s.impressions},
+		config.ClicksConfig{},
+		config.OutboxConfig{},
+	)
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(1).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(1)
+	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Times(1).Return(fakeStatsSlice(), nil)
+	s.mockRepo.EXPECT().PersistShow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, _, err := rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	s.Require().Error(err)
+}
+
+// TestNextBannerIDRetriedUnitOfWork checks that banner chosen by the last attempt of unit of work is returned.
+func (s RotationSuite) TestNextBannerIDRetriedUnitOfWork() {
+	testStats := fakeStatsSliceWithEmptyStats(2)
+	testStats[0].ShowAmount = sql.NullInt64{Int64: 1, Valid: true}
+	retriedStats := fakeStatsSliceWithEmptyStats(2)
+	retriedStats[0].BannerID = testStats[0].BannerID
+	retriedStats[1].BannerID = testStats[1].BannerID
+	retriedStats[1].ShowAmount = sql.NullInt64{Int64: 1, Valid: true}
+
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(1).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.mockRepo.EXPECT().InStatsTx(s.ctx, gomock.Any()).Times(1).DoAndReturn(
+		func(_ context.Context, fn func(tx storage.StatsTx) error) error {
+			// first attempt is rolled back as if it conflicted with concurrent transaction
+			if err := fn(s.mockRepo); err != nil {
+				return err
+			}
+			return fn(s.mockRepo)
+		})
+	gomock.InOrder(
+		s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Return(testStats, nil),
+		s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, testStats[1].BannerID).Return(nil),
+		s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Return(retriedStats, nil),
+		s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, testStats[0].BannerID).Return(nil),
+	)
+
+	id, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	s.Require().NoError(err)
+	s.Require().Equal(testStats[0].BannerID, id)
+}
+
+func (s RotationSuite) TestNextBannerIDTxConflict() {
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(1).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.mockRepo.EXPECT().InStatsTx(s.ctx, gomock.Any()).Times(1).Return(storage.ErrTxConflict)
+	s.mockPublisher.EXPECT().Publish(gomock.Any()).Times(0)
+
+	_, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	s.Require().True(errors.Is(err, storage.ErrTxConflict))
 }
 
 // TestNextBannerIdAllShownAtLeastOnce - test purpose is to check all banners should be shown at least once.
@@ -171,6 +551,8 @@ func (s RotationSuite) TestAllBannersShownAtLeastOnce() {
 	testSlotID := faker.UUIDHyphenated()
 	testGroupID := faker.UUIDHyphenated()
 
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(100).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(100)
 	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Times(100).Return(testStats, nil)
 	s.mockRepo.EXPECT().PersistShow(
 		s.ctx,
@@ -181,16 +563,9 @@ func (s RotationSuite) TestAllBannersShownAtLeastOnce() {
 		increaseShows(testStats, resBannerId)
 		return nil
 	}).Times(100)
-	s.mockPublisher.EXPECT().Publish(messageMatcher{stats.Message{
-		BannerID:  "any",
-		SlotID:    testSlotID,
-		GroupID:   testGroupID,
-		Type:      "show",
-		Timestamp: time.Now(),
-	}}).Times(100).Return(nil)
 
 	for _, v := range testStats {
-		id, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+		id, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
 		s.Require().NoError(err)
 		s.Require().Equal(v.BannerID, id)
 	}
@@ -206,6 +581,11 @@ func (s RotationSuite) TestMorePopularBannersShownMoreOften() {
 	testSlotID := faker.UUIDHyphenated()
 	testGroupID := faker.UUIDHyphenated()
 
+	s.mockRepo.EXPECT().GetSlotSettings(
+		s.ctx,
+		testSlotID,
+	).Times(numOfShows).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(numOfShows)
 	s.mockRepo.EXPECT().FindSlotBannerStats(
 		s.ctx,
 		testSlotID,
@@ -221,14 +601,6 @@ func (s RotationSuite) TestMorePopularBannersShownMoreOften() {
 		return nil
 	}).Times(numOfShows)
 
-	s.mockPublisher.EXPECT().Publish(messageMatcher{stats.Message{
-		BannerID:  "any",
-		SlotID:    testSlotID,
-		GroupID:   testGroupID,
-		Type:      "show",
-		Timestamp: time.Now(),
-	}}).Times(numOfShows).Return(nil)
-
 	s.mockRepo.EXPECT().PersistClick(
 		s.ctx,
 		testSlotID,
@@ -239,18 +611,10 @@ func (s RotationSuite) TestMorePopularBannersShownMoreOften() {
 		return nil
 	}).MaxTimes(numOfShows)
 
-	s.mockPublisher.EXPECT().Publish(messageMatcher{stats.Message{
-		BannerID:  "any",
-		SlotID:    testSlotID,
-		GroupID:   testGroupID,
-		Type:      "click",
-		Timestamp: time.Now(),
-	}}).MaxTimes(numOfShows).Return(nil)
-
 	popularBannersShows := 0
 	unpopularBannersShows := 0
 	for i := 0; i < numOfShows; i++ {
-		id, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+		id, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
 		s.Require().NoError(err)
 		if isPopularBanner(testStats, id) {
 			popularBannersShows++
@@ -267,6 +631,474 @@ func (s RotationSuite) TestMorePopularBannersShownMoreOften() {
 	s.Require().True(popularBannersShows > unpopularBannersShows)
 }
 
+// TestNextBannerIDs - test purpose is to check that distinct banners are returned and all shows are persisted.
+func (s RotationSuite) TestNextBannerIDs() {
+	testStats := fakeStatsSliceWithEmptyStats(5)
+
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(2).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(2)
+	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Times(2).Return(testStats, nil)
+	s.mockRepo.EXPECT().PersistShows(s.ctx, testSlotID, testGroupID, []string{
+		testStats[0].BannerID,
+		testStats[1].BannerID,
+		testStats[2].BannerID,
+	}).Times(1).Return(nil)
+	s.mockRepo.EXPECT().PersistShows(s.ctx, testSlotID, testGroupID, gomock.Len(5)).Times(1).Return(nil)
+
+	ids, _, err := s.rotationService.NextBannerIDs(s.ctx, testSlotID, testGroupID, 3)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{testStats[0].BannerID, testStats[1].BannerID, testStats[2].BannerID}, ids)
+
+	// if slot has less banners than requested, all slot banners are returned
+	ids, _, err = s.rotationService.NextBannerIDs(s.ctx, testSlotID, testGroupID, 10)
+	s.Require().NoError(err)
+	s.Require().Len(ids, 5)
+}
+
+// TestNextBannerIDsForSlots - test purpose is to check that banners for all slots are chosen with batched calls
+// in one unit of work.
+func (s RotationSuite) TestNextBannerIDsForSlots() {
+	firstSlotID := faker.UUIDHyphenated()
+	secondSlotID := faker.UUIDHyphenated()
+	emptySlotID := faker.UUIDHyphenated()
+	windowedSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	slotIDs := []string{firstSlotID, emptySlotID, secondSlotID, windowedSlotID}
+
+	firstStats := fakeStatsSliceWithEmptyStats(3)
+	secondStats := fakeStatsSliceWithEmptyStats(3)
+	windowedStats := fakeStatsSliceWithEmptyStats(3)
+
+	s.mockRepo.EXPECT().GetSlotsSettings(s.ctx, slotIDs).Times(1).Return([]storage.SlotSettings{
+		{SlotID: firstSlotID},
+		{SlotID: secondSlotID},
+		{SlotID: emptySlotID},
+		{
+			SlotID:        windowedSlotID,
+			Strategy:      sql.NullString{String: services.SlidingWindowUCB1StrategyName, Valid: true},
+			WindowSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+		},
+	}, nil)
+	s.expectStatsTx(1)
+	s.mockRepo.EXPECT().FindSlotsBannerStats(
+		s.ctx,
+		[]string{firstSlotID, secondSlotID, emptySlotID},
+		testGroupID,
+	).Times(1).Return(map[string][]storage.SlotBannerStat{
+		firstSlotID:  firstStats,
+		secondSlotID: secondStats,
+	}, nil)
+	s.mockRepo.EXPECT().FindSlotsBannerStatsSince(
+		s.ctx,
+		[]string{windowedSlotID},
+		testGroupID,
+		gomock.Any(),
+	).Times(1).Return(map[string][]storage.SlotBannerStat{windowedSlotID: windowedStats}, nil)
+	s.mockRepo.EXPECT().PersistSlotsShows(s.ctx, testGroupID, []storage.SlotBanner{
+		{SlotID: firstSlotID, BannerID: firstStats[0].BannerID},
+		{SlotID: secondSlotID, BannerID: secondStats[0].BannerID},
+		{SlotID: windowedSlotID, BannerID: windowedStats[0].BannerID},
+	}).Times(1).Return(nil)
+
+	slotBanners, tokens, err := s.rotationService.NextBannerIDsForSlots(s.ctx, slotIDs, testGroupID)
+	s.Require().NoError(err)
+	s.Require().Equal([]storage.SlotBanner{
+		{SlotID: firstSlotID, BannerID: firstStats[0].BannerID},
+		{SlotID: emptySlotID},
+		{SlotID: secondSlotID, BannerID: secondStats[0].BannerID},
+		{SlotID: windowedSlotID, BannerID: windowedStats[0].BannerID},
+	}, slotBanners)
+	s.Require().Len(tokens, len(slotIDs))
+	s.Require().Empty(tokens[1])
+	for _, i := range []int{0, 2, 3} {
+		imp, err := s.impressions.Verify(tokens[i])
+		s.Require().NoError(err)
+		s.Require().Equal(slotBanners[i].SlotID, imp.SlotID)
+		s.Require().Equal(slotBanners[i].BannerID, imp.BannerID)
+	}
+}
+
+func (s RotationSuite) TestNextBannerIDsForSlotsNotFound() {
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+
+	s.mockRepo.EXPECT().GetSlotsSettings(s.ctx, []string{testSlotID}).Times(1).Return(nil, nil)
+
+	_, _, err := s.rotationService.NextBannerIDsForSlots(s.ctx, []string{testSlotID}, testGroupID)
+	s.Require().True(errors.Is(err, storage.ErrSlotNotFound))
+}
+
+func (s RotationSuite) TestNextBannerIDsForSlotsTooManySlots() {
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		s.mockPublisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{MaxBatchSlots: 2},
+		s.impressions,
+		config.ClicksConfig{},
+		config.OutboxConfig{},
+	)
+	slotIDs := []string{faker.UUIDHyphenated(), faker.UUIDHyphenated(), faker.UUIDHyphenated()}
+
+	_, _, err := rotationService.NextBannerIDsForSlots(s.ctx, slotIDs, faker.UUIDHyphenated())
+	s.Require().True(errors.Is(err, services.ErrTooManySlots))
+}
+
+// TestNextBannerIDSlotSettings - test purpose is to check that slot settings override default strategy.
+func (s RotationSuite) TestNextBannerIDSlotSettings() {
+	testStats := fakeStatsSliceWithEmptyStats(10)
+	for ind := range testStats {
+		testStats[ind].ShowAmount.Int64 = 100
+		testStats[ind].ClickAmount.Int64 = int64(ind)
+	}
+	// banner with few shows is preferred by ucb1 but never chosen by greedy strategy
+	testStats[0].ShowAmount.Int64 = 1
+	testStats[0].ClickAmount.Int64 = 0
+
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	settings := storage.SlotSettings{
+		SlotID:   testSlotID,
+		Strategy: sql.NullString{String: services.EpsilonGreedyStrategyName, Valid: true},
+		Epsilon:  sql.NullFloat64{Float64: 0, Valid: true},
+	}
+
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(2).Return(settings, nil)
+	s.expectStatsTx(2)
+	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Times(2).Return(testStats, nil)
+	s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, testStats[9].BannerID).Times(2).Return(nil)
+
+	for i := 0; i < 2; i++ {
+		id, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+		s.Require().NoError(err)
+		s.Require().Equal(testStats[9].BannerID, id)
+	}
+}
+
+func (s RotationSuite) TestSetSlotSettings() {
+	testSlotID := faker.UUIDHyphenated()
+	settings := storage.SlotSettings{
+		SlotID:  testSlotID,
+		Epsilon: sql.NullFloat64{Float64: 0.2, Valid: true},
+	}
+	s.mockRepo.EXPECT().SetSlotSettings(s.ctx, settings).Times(1).Return(nil)
+	s.Require().NoError(s.rotationService.SetSlotSettings(s.ctx, settings))
+
+	settings.Strategy = sql.NullString{String: "unknown", Valid: true}
+	err := s.rotationService.SetSlotSettings(s.ctx, settings)
+	s.Require().True(errors.Is(err, services.ErrUnknownStrategy))
+
+	settings.Strategy = sql.NullString{String: services.ThompsonStrategyName, Valid: true}
+	settings.ThompsonAlpha = sql.NullFloat64{Float64: -1, Valid: true}
+	err = s.rotationService.SetSlotSettings(s.ctx, settings)
+	s.Require().True(errors.Is(err, services.ErrInvalidStrategyParams))
+
+	settings.Strategy = sql.NullString{String: services.UCB1StrategyName, Valid: true}
+	settings.ExplorationConstant = sql.NullFloat64{Float64: math.NaN(), Valid: true}
+	err = s.rotationService.SetSlotSettings(s.ctx, settings)
+	s.Require().True(errors.Is(err, services.ErrInvalidStrategyParams))
+}
+
+// TestSlotStrategyReplaced checks that strategy of the slot is kept between requests
+// and is created again after the slot settings change or the slot deletion.
+func (s RotationSuite) TestSlotStrategyReplaced() {
+	var created int32
+	services.RegisterStrategy("counting slot strategy", func(_ config.RotationConfig) (services.Strategy, error) {
+		atomic.AddInt32(&created, 1)
+		return firstBannerStrategy{}, nil
+	})
+	testStats := fakeStatsSlice()
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	settings := storage.SlotSettings{
+		SlotID:   testSlotID,
+		Strategy: sql.NullString{String: "counting slot strategy", Valid: true},
+		Epsilon:  sql.NullFloat64{Float64: 0.1, Valid: true},
+	}
+	nextBanner := func() {
+		s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Return(settings, nil)
+		s.expectStatsTx(1)
+		s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).Return(testStats, nil)
+		s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, testStats[0].BannerID).Return(nil)
+		_, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+		s.Require().NoError(err)
+	}
+
+	nextBanner()
+	nextBanner()
+	s.Require().Equal(int32(1), atomic.LoadInt32(&created))
+
+	settings.Epsilon = sql.NullFloat64{Float64: 0.2, Valid: true}
+	s.mockRepo.EXPECT().SetSlotSettings(s.ctx, settings).Return(nil)
+	s.Require().NoError(s.rotationService.SetSlotSettings(s.ctx, settings))
+	nextBanner()
+	s.Require().Equal(int32(2), atomic.LoadInt32(&created))
+
+	s.mockRepo.EXPECT().DeleteSlot(s.ctx, testSlotID).Return(nil)
+	s.Require().NoError(s.rotationService.DeleteSlot(s.ctx, testSlotID))
+	nextBanner()
+	s.Require().Equal(int32(3), atomic.LoadInt32(&created))
+}
+
+// TestApplyCatalogChange checks that strategy of the slot is created again after the slot banners
+// or unknown catalog change made by any service instance.
+func (s RotationSuite) TestApplyCatalogChange() {
+	var created int32
+	services.RegisterStrategy("counting changed slot strategy", func(_ config.RotationConfig) (services.Strategy, error) {
+		atomic.AddInt32(&created, 1)
+		return firstBannerStrategy{}, nil
+	})
+	testStats := fakeStatsSlice()
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	settings := storage.SlotSettings{
+		SlotID:   testSlotID,
+		Strategy: sql.NullString{String: "counting changed slot strategy", Valid: true},
+	}
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).AnyTimes().Return(settings, nil)
+	s.mockRepo.EXPECT().InStatsTx(s.ctx, gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, fn func(tx storage.StatsTx) error) error {
+			return fn(s.mockRepo)
+		})
+	s.mockRepo.EXPECT().FindSlotBannerStats(s.ctx, testSlotID, testGroupID).AnyTimes().Return(testStats, nil)
+	s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, testStats[0].BannerID).AnyTimes().Return(nil)
+
+	changes := []struct {
+		change          storage.CatalogChange
+		expectedCreated int32
+	}{
+		{change: storage.CatalogChange{SlotID: faker.UUIDHyphenated()}, expectedCreated: 1},
+		{change: storage.CatalogChange{GroupID: testGroupID}, expectedCreated: 1},
+		{change: storage.CatalogChange{SlotID: testSlotID, BannerID: testStats[0].BannerID}, expectedCreated: 2},
+		{change: storage.CatalogChange{}, expectedCreated: 3},
+	}
+	_, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	s.Require().NoError(err)
+	for _, c := range changes {
+		s.rotationService.ApplyCatalogChange(c.change)
+		_, _, err := s.rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+		s.Require().NoError(err)
+		s.Require().Equal(c.expectedCreated, atomic.LoadInt32(&created))
+	}
+}
+
+func (s RotationSuite) TestGetSlotStats() {
+	slot, err := fakeSlot()
+	s.Require().NoError(err)
+	group, err := fakeGroup()
+	s.Require().NoError(err)
+	s.mockRepo.EXPECT().GetSlotByID(s.ctx, slot.ID).AnyTimes().Return(slot, nil)
+	s.mockRepo.EXPECT().FindSlotBanners(s.ctx, slot.ID).AnyTimes().Return([]storage.Banner{
+		{ID: "banner"},
+		{ID: "not shown banner"},
+	}, nil)
+
+	// all time stats of the group are summed from all buckets, all slot banners are returned
+	allTimeFilter := storage.StatsBucketsFilter{SlotID: slot.ID, GroupID: group.ID}
+	s.mockRepo.EXPECT().FindStatsBuckets(s.ctx, allTimeFilter).Return([]storage.StatsBucket{
+		{SlotID: slot.ID, GroupID: group.ID, BannerID: "banner", BucketStart: time.Unix(0, 0), Clicks: 1, Shows: 3},
+		{SlotID: slot.ID, GroupID: group.ID, BannerID: "banner", BucketStart: time.Now(), Shows: 1},
+	}, nil)
+	stats, err := s.rotationService.GetSlotStats(s.ctx, slot.ID, group.ID, time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Require().Len(stats, 2)
+	s.Require().Equal(group.ID, stats[0].GroupID)
+	s.Require().Equal(0.25, stats[0].GetCTR())
+	s.Require().Equal("not shown banner", stats[1].BannerID)
+	s.Require().False(stats[1].ShowAmount.Valid)
+
+	// stats of all groups for the time range are summed from buckets, removed banners are skipped
+	from := time.Date(2021, 8, 12, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour * 24)
+	filter := storage.StatsBucketsFilter{SlotID: slot.ID, From: from, To: to}
+	s.mockRepo.EXPECT().FindStatsBuckets(s.ctx, filter).Return([]storage.StatsBucket{
+		{SlotID: slot.ID, GroupID: "group 2", BannerID: "banner", BucketStart: from, Clicks: 1, Shows: 2},
+		{SlotID: slot.ID, GroupID: "group 1", BannerID: "banner", BucketStart: from, Clicks: 0, Shows: 3},
+		{SlotID: slot.ID, GroupID: "group 2", BannerID: "banner", BucketStart: from.Add(time.Hour), Clicks: 1, Shows: 2},
+		{SlotID: slot.ID, GroupID: "group 1", BannerID: "removed banner", BucketStart: from, Clicks: 1, Shows: 1},
+	}, nil)
+	stats, err = s.rotationService.GetSlotStats(s.ctx, slot.ID, "", from, to)
+	s.Require().NoError(err)
+	s.Require().Len(stats, 2)
+	s.Require().Equal("group 1", stats[0].GroupID)
+	s.Require().Equal(int64(3), stats[0].GetShows())
+	s.Require().Equal(0.0, stats[0].GetCTR())
+	s.Require().Equal("group 2", stats[1].GroupID)
+	s.Require().Equal(int64(4), stats[1].GetShows())
+	s.Require().Equal(int64(2), stats[1].GetClicks())
+	s.Require().Equal(0.5, stats[1].GetCTR())
+}
+
+// TestGetSlotStatsAllTimeRange checks that stats for the range which covers all time match stats without range.
+func (s RotationSuite) TestGetSlotStatsAllTimeRange() {
+	rotationService := services.NewRotationService(
+		memory.NewStorage(),
+		s.mockPublisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{},
+		config.OutboxConfig{},
+	)
+	slot, err := rotationService.AddSlot(s.ctx, "slot")
+	s.Require().NoError(err)
+	group, err := rotationService.AddGroup(s.ctx, "group")
+	s.Require().NoError(err)
+	for i := 0; i < 3; i++ {
+		banner, err := rotationService.AddBanner(s.ctx, fmt.Sprintf("banner %d", i))
+		s.Require().NoError(err)
+		s.Require().NoError(rotationService.AddBannerToSlot(s.ctx, slot.ID, banner.ID))
+	}
+	for i := 0; i < 10; i++ {
+		id, _, err := rotationService.NextBannerID(s.ctx, slot.ID, group.ID)
+		s.Require().NoError(err)
+		s.Require().NoError(rotationService.PersistClick(s.ctx, slot.ID, group.ID, id))
+		if i == 0 {
+			// stats of the removed banner are not slot stats anymore
+			s.Require().NoError(rotationService.DeleteBannerFromSlot(s.ctx, slot.ID, id))
+		}
+	}
+
+	from := time.Now().Add(-time.Hour * 24 * 365)
+	to := time.Now().Add(time.Hour)
+	for _, groupID := range []string{group.ID, ""} {
+		stats, err := rotationService.GetSlotStats(s.ctx, slot.ID, groupID, time.Time{}, time.Time{})
+		s.Require().NoError(err)
+		rangeStats, err := rotationService.GetSlotStats(s.ctx, slot.ID, groupID, from, to)
+		s.Require().NoError(err)
+		s.Require().Equal(stats, rangeStats)
+		var shows int64
+		for _, stat := range stats {
+			shows += stat.GetShows()
+		}
+		s.Require().Equal(int64(9), shows)
+	}
+}
+
+func (s RotationSuite) TestGetSlotStatsNotFound() {
+	s.mockRepo.EXPECT().GetSlotByID(s.ctx, "unknown").Return(storage.Slot{}, storage.ErrSlotNotFound)
+	_, err := s.rotationService.GetSlotStats(s.ctx, "unknown", "", time.Time{}, time.Time{})
+	s.Require().True(errors.Is(err, storage.ErrSlotNotFound))
+}
+
+func (s RotationSuite) TestUpdateBanner() {
+	banner, err := fakeBanner()
+	s.Require().NoError(err)
+
+	s.mockRepo.EXPECT().UpdateBanner(s.ctx, banner).Return(nil)
+	updated, err := s.rotationService.UpdateBanner(s.ctx, banner)
+	s.Require().NoError(err)
+	s.Require().Equal(banner, updated)
+
+	s.mockRepo.EXPECT().UpdateBanner(s.ctx, banner).Return(storage.ErrBannerNotFound)
+	_, err = s.rotationService.UpdateBanner(s.ctx, banner)
+	s.Require().True(errors.Is(err, storage.ErrBannerNotFound))
+}
+
+func (s RotationSuite) TestListSlots() {
+	slots := make([]storage.Slot, 3)
+	for i := range slots {
+		slots[i] = storage.Slot{ID: faker.UUIDHyphenated()}
+	}
+
+	s.mockRepo.EXPECT().ListSlots(s.ctx, "", 3).Return(slots, nil)
+	page, nextPageToken, err := s.rotationService.ListSlots(s.ctx, "", 2)
+	s.Require().NoError(err)
+	s.Require().Equal(slots[:2], page)
+	s.Require().NotEmpty(nextPageToken)
+
+	s.mockRepo.EXPECT().ListSlots(s.ctx, slots[1].ID, 3).Return(slots[2:], nil)
+	page, nextPageToken, err = s.rotationService.ListSlots(s.ctx, nextPageToken, 2)
+	s.Require().NoError(err)
+	s.Require().Equal(slots[2:], page)
+	s.Require().Empty(nextPageToken)
+
+	// page size is limited
+	s.mockRepo.EXPECT().ListSlots(s.ctx, "", services.DefaultPageSize+1).Return(nil, nil)
+	_, _, err = s.rotationService.ListSlots(s.ctx, "", 0)
+	s.Require().NoError(err)
+	s.mockRepo.EXPECT().ListSlots(s.ctx, "", services.MaxPageSize+1).Return(nil, nil)
+	_, _, err = s.rotationService.ListSlots(s.ctx, "", services.MaxPageSize*2)
+	s.Require().NoError(err)
+
+	_, _, err = s.rotationService.ListSlots(s.ctx, "not a token!", 2)
+	s.Require().True(errors.Is(err, services.ErrInvalidPageToken))
+	// well-formed token with garbage cursor
+	garbageToken := base64.RawURLEncoding.EncodeToString([]byte("slot 1' OR 1=1"))
+	_, _, err = s.rotationService.ListSlots(s.ctx, garbageToken, 2)
+	s.Require().True(errors.Is(err, services.ErrInvalidPageToken))
+}
+
+func (s RotationSuite) TestListSlotBanners() {
+	slot, err := fakeSlot()
+	s.Require().NoError(err)
+	banner, err := fakeBanner()
+	s.Require().NoError(err)
+
+	s.mockRepo.EXPECT().GetSlotByID(s.ctx, slot.ID).Return(slot, nil)
+	s.mockRepo.EXPECT().FindSlotBanners(s.ctx, slot.ID).Return([]storage.Banner{banner}, nil)
+	banners, err := s.rotationService.ListSlotBanners(s.ctx, slot.ID)
+	s.Require().NoError(err)
+	s.Require().Equal([]storage.Banner{banner}, banners)
+
+	s.mockRepo.EXPECT().GetSlotByID(s.ctx, "unknown").Return(storage.Slot{}, storage.ErrSlotNotFound)
+	_, err = s.rotationService.ListSlotBanners(s.ctx, "unknown")
+	s.Require().True(errors.Is(err, storage.ErrSlotNotFound))
+}
+
+// TestMemoryStorageRotation runs rotation service over in-memory storage without any mocked repository calls.
+func (s RotationSuite) TestMemoryStorageRotation() {
+	rotationService := services.NewRotationService(
+		memory.NewStorage(),
+		s.mockPublisher,
+		services.NewUCB1Strategy(),
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{DedupWindow: time.Minute},
+		config.OutboxConfig{BatchSize: 100, Lease: time.Minute, Retention: time.Hour},
+	)
+
+	slot, err := rotationService.AddSlot(s.ctx, "slot")
+	s.Require().NoError(err)
+	group, err := rotationService.AddGroup(s.ctx, "group")
+	s.Require().NoError(err)
+	bannerIDs := make(map[string]int)
+	for i := 0; i < 5; i++ {
+		banner, err := rotationService.AddBanner(s.ctx, fmt.Sprintf("banner %d", i))
+		s.Require().NoError(err)
+		s.Require().NoError(rotationService.AddBannerToSlot(s.ctx, slot.ID, banner.ID))
+		bannerIDs[banner.ID] = 0
+	}
+
+	err = rotationService.PersistClick(s.ctx, slot.ID, group.ID, slot.ID)
+	s.Require().True(errors.Is(err, storage.ErrBannerNotShown))
+	for i := 0; i < 50; i++ {
+		id, _, err := rotationService.NextBannerID(s.ctx, slot.ID, group.ID)
+		s.Require().NoError(err)
+		s.Require().Contains(bannerIDs, id)
+		bannerIDs[id]++
+	}
+	for id, shows := range bannerIDs {
+		s.Require().Positive(shows, "banner %s was never shown", id)
+		s.Require().NoError(rotationService.PersistClick(s.ctx, slot.ID, group.ID, id))
+	}
+	// 50 shows and a click of each banner
+	s.mockPublisher.EXPECT().Publish(gomock.Any()).Times(55).Return(nil)
+	sent, err := rotationService.RelayOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(55, sent)
+	sent, err = rotationService.RelayOutboxEvents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(sent)
+
+	s.Require().NoError(rotationService.DeleteSlot(s.ctx, slot.ID))
+	_, _, err = rotationService.NextBannerID(s.ctx, slot.ID, group.ID)
+	s.Require().True(errors.Is(err, storage.ErrSlotNotFound))
+}
+
 func fakeBanner() (storage.Banner, error) {
 	var banner storage.Banner
 	err := faker.FakeData(&banner)
@@ -347,34 +1179,39 @@ func isPopularBanner(stats []storage.SlotBannerStat, bannerID string) bool {
 	return false
 }
 
-type messageMatcher struct {
-	stats.Message
-}
-
-func (m messageMatcher) Matches(x interface{}) bool {
-	m2, ok := x.(stats.Message)
-	if !ok {
-		return false
-	}
-	return m2.GroupID == m.GroupID && m2.SlotID == m.SlotID && m2.Type == m.Type
-}
+// TestNextBannerIDWindowedStrategy - test purpose is to check that windowed strategy gets only recent stats.
+func (s RotationSuite) TestNextBannerIDWindowedStrategy() {
+	testStats := fakeStatsSlice()
 
-func (m messageMatcher) String() string {
-	return fmt.Sprintf("is equal to %+v", m.Message)
-}
+	testSlotID := faker.UUIDHyphenated()
+	testGroupID := faker.UUIDHyphenated()
+	window := time.Hour * 3
 
-type withBannerIDMsgMatcher struct {
-	messageMatcher
-}
+	strategy, err := services.NewSlidingWindowUCB1Strategy(services.NewUCB1Strategy(), window)
+	s.Require().NoError(err)
+	rotationService := services.NewRotationService(
+		s.mockRepo,
+		s.mockPublisher,
+		strategy,
+		config.RotationConfig{},
+		s.impressions,
+		config.ClicksConfig{DedupWindow: time.Minute},
+		config.OutboxConfig{BatchSize: 100, Lease: time.Minute, Retention: time.Hour},
+	)
 
-func (m withBannerIDMsgMatcher) Matches(x interface{}) bool {
-	m2, ok := x.(stats.Message)
-	if !ok {
-		return false
-	}
-	return m2.BannerID == m.BannerID && m2.GroupID == m.GroupID && m2.SlotID == m.SlotID && m2.Type == m.Type
-}
+	s.mockRepo.EXPECT().GetSlotSettings(s.ctx, testSlotID).Times(1).Return(storage.SlotSettings{SlotID: testSlotID}, nil)
+	s.expectStatsTx(1)
+	s.mockRepo.EXPECT().FindSlotBannerStatsSince(
+		s.ctx,
+		testSlotID,
+		testGroupID,
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ string, _ string, since time.Time) ([]storage.SlotBannerStat, error) {
+		s.Require().WithinDuration(time.Now().Add(-window), since, time.Minute)
+		return testStats, nil
+	}).Times(1)
+	s.mockRepo.EXPECT().PersistShow(s.ctx, testSlotID, testGroupID, gomock.Any()).Times(1).Return(nil)
 
-func matchWithBannerID(m messageMatcher) withBannerIDMsgMatcher {
-	return withBannerIDMsgMatcher{m}
+	_, _, err = rotationService.NextBannerID(s.ctx, testSlotID, testGroupID)
+	s.Require().NoError(err)
 }
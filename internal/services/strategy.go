@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+var (
+	ErrUnknownStrategy       = errors.New("unknown rotation strategy")
+	ErrInvalidStrategyParams = errors.New("invalid rotation strategy parameters")
+)
+
+// Strategy is a multiarmed bandit algorithm which chooses banner to show next.
+// Strategy implementations must be safe for concurrent use,
+// bannerStats passed to them are always not empty.
+type Strategy interface {
+	NextBannerID(ctx context.Context, bannerStats []storage.SlotBannerStat) string
+}
+
+// WindowedStrategy is a Strategy which should get only banner stats collected during the recent time window.
+// It allows to adapt rotation for changing user preferences.
+type WindowedStrategy interface {
+	Strategy
+	Window() time.Duration
+}
+
+// StrategyFactory creates new Strategy instance using rotation config parameters.
+type StrategyFactory func(cnf config.RotationConfig) (Strategy, error)
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StrategyFactory{
+		UCB1StrategyName: func(cnf config.RotationConfig) (Strategy, error) {
+			return newUCB1Strategy(cnf)
+		},
+		SlidingWindowUCB1StrategyName:     newSlidingWindowUCB1Strategy,
+		ThompsonStrategyName:              newThompsonStrategy,
+		EpsilonGreedyStrategyName:         newEpsilonGreedyStrategy,
+		DecayingEpsilonGreedyStrategyName: newDecayingEpsilonGreedyStrategy,
+	}
+)
+
+// RegisterStrategy makes strategy available by name for NewStrategy function.
+// If strategy with the same name was already registered it will be replaced.
+func RegisterStrategy(name string, factory StrategyFactory) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[strings.ToLower(name)] = factory
+}
+
+// StrategyNames returns sorted names of all registered strategies.
+func StrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewStrategy creates strategy registered with name from config.
+// If strategy name is empty, UCB1 strategy is used.
+func NewStrategy(cnf config.RotationConfig) (Strategy, error) {
+	name := strings.ToLower(strings.TrimSpace(cnf.Strategy))
+	if name == "" {
+		name = UCB1StrategyName
+	}
+
+	strategiesMu.RLock()
+	factory, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, available strategies: %s",
+			ErrUnknownStrategy, name, strings.Join(StrategyNames(), ", "))
+	}
+
+	strategy, err := factory(cnf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %q strategy: %w", name, err)
+	}
+	return strategy, nil
+}
+
+// RankBanners returns up to count distinct banner ids in order they are chosen by strategy,
+// each next banner is chosen by strategy among banners which were not chosen yet.
+func RankBanners(ctx context.Context, strategy Strategy, bannerStats []storage.SlotBannerStat, count int) []string {
+	candidates := make([]storage.SlotBannerStat, len(bannerStats))
+	copy(candidates, bannerStats)
+
+	bannerIDs := make([]string, 0, count)
+	for len(candidates) > 0 && len(bannerIDs) < count {
+		bannerID := strategy.NextBannerID(ctx, candidates)
+		bannerIDs = append(bannerIDs, bannerID)
+		for ind, candidate := range candidates {
+			if candidate.BannerID == bannerID {
+				candidates = append(candidates[:ind], candidates[ind+1:]...)
+				break
+			}
+		}
+	}
+	return bannerIDs
+}
+
+// ApplySlotSettings returns rotation config where default values are replaced with slot specific settings.
+func ApplySlotSettings(defaults config.RotationConfig, settings storage.SlotSettings) config.RotationConfig {
+	cnf := defaults
+	if settings.Strategy.Valid {
+		cnf.Strategy = settings.Strategy.String
+	}
+	if settings.ExplorationConstant.Valid {
+		cnf.ExplorationConstant = settings.ExplorationConstant.Float64
+	}
+	if settings.Epsilon.Valid {
+		cnf.Epsilon = settings.Epsilon.Float64
+	}
+	if settings.EpsilonDecay.Valid {
+		cnf.EpsilonDecay = settings.EpsilonDecay.Float64
+	}
+	if settings.ThompsonAlpha.Valid {
+		cnf.ThompsonAlpha = settings.ThompsonAlpha.Float64
+	}
+	if settings.ThompsonBeta.Valid {
+		cnf.ThompsonBeta = settings.ThompsonBeta.Float64
+	}
+	if settings.WindowSeconds.Valid {
+		cnf.Window = time.Duration(settings.WindowSeconds.Int64) * time.Second
+	}
+	return cnf
+}
+
+// strategyCache keeps strategies created for slot specific settings, so strategy state
+// (e.g. random generator) is kept between requests. Strategy of the slot is replaced when its settings are changed.
+type strategyCache struct {
+	mu         sync.Mutex
+	strategies map[string]slotStrategy
+}
+
+type slotStrategy struct {
+	cnf      config.RotationConfig
+	strategy Strategy
+}
+
+func newStrategyCache() *strategyCache {
+	return &strategyCache{strategies: make(map[string]slotStrategy)}
+}
+
+// get returns strategy of the slot created for the config, it's created again if the config was changed.
+func (c *strategyCache) get(slotID string, cnf config.RotationConfig) (Strategy, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.strategies[slotID]; ok && cached.cnf == cnf {
+		return cached.strategy, nil
+	}
+	strategy, err := NewStrategy(cnf)
+	if err != nil {
+		return nil, err
+	}
+	c.strategies[slotID] = slotStrategy{cnf: cnf, strategy: strategy}
+	return strategy, nil
+}
+
+func (c *strategyCache) put(slotID string, cnf config.RotationConfig, strategy Strategy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strategies[slotID] = slotStrategy{cnf: cnf, strategy: strategy}
+}
+
+func (c *strategyCache) delete(slotID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.strategies, slotID)
+}
+
+func (c *strategyCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strategies = make(map[string]slotStrategy)
+}
@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/services"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+type firstBannerStrategy struct{}
+
+func (f firstBannerStrategy) NextBannerID(_ context.Context, bannerStats []storage.SlotBannerStat) string {
+	return bannerStats[0].BannerID
+}
+
+func TestNewStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := services.NewStrategy(config.RotationConfig{})
+	require.NoError(t, err)
+	require.IsType(t, services.UCB1Strategy{}, strategy)
+
+	strategy, err = services.NewStrategy(config.RotationConfig{Strategy: " UCB1 "})
+	require.NoError(t, err)
+	require.IsType(t, services.UCB1Strategy{}, strategy)
+
+	_, err = services.NewStrategy(config.RotationConfig{Strategy: "unknown"})
+	require.True(t, errors.Is(err, services.ErrUnknownStrategy))
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	t.Parallel()
+
+	services.RegisterStrategy("first", func(_ config.RotationConfig) (services.Strategy, error) {
+		return firstBannerStrategy{}, nil
+	})
+	require.Contains(t, services.StrategyNames(), "first")
+
+	strategy, err := services.NewStrategy(config.RotationConfig{Strategy: "first"})
+	require.NoError(t, err)
+
+	testStats := fakeStatsSlice()
+	require.Equal(t, testStats[0].BannerID, strategy.NextBannerID(context.Background(), testStats))
+}
+
+func TestThompsonStrategy(t *testing.T) {
+	t.Parallel()
+
+	_, err := services.NewThompsonStrategy(0, 1, 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+	_, err = services.NewThompsonStrategy(1, math.NaN(), 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+
+	t.Run("same seed gives same choices", func(t *testing.T) {
+		t.Parallel()
+		testStats := fakeStatsSlice()
+		first, err := services.NewThompsonStrategy(1, 1, 42)
+		require.NoError(t, err)
+		second, err := services.NewThompsonStrategy(1, 1, 42)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			require.Equal(t,
+				first.NextBannerID(context.Background(), testStats),
+				second.NextBannerID(context.Background(), testStats),
+			)
+		}
+	})
+
+	t.Run("popular banner chosen more often", func(t *testing.T) {
+		t.Parallel()
+		testStats := fakeStatsSliceWithEmptyStats(10)
+		for ind := range testStats {
+			testStats[ind].ShowAmount.Int64 = 100
+			testStats[ind].ClickAmount.Int64 = 1
+		}
+		testStats[3].ClickAmount.Int64 = 30
+
+		strategy, err := services.NewThompsonStrategy(1, 1, 42)
+		require.NoError(t, err)
+		popularBannerShows := 0
+		for i := 0; i < 1000; i++ {
+			if strategy.NextBannerID(context.Background(), testStats) == testStats[3].BannerID {
+				popularBannerShows++
+			}
+		}
+		require.Greater(t, popularBannerShows, 900)
+	})
+}
+
+func TestEpsilonGreedyStrategy(t *testing.T) {
+	t.Parallel()
+
+	_, err := services.NewEpsilonGreedyStrategy(1.5, 0, 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+	_, err = services.NewEpsilonGreedyStrategy(0.1, -1, 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+	_, err = services.NewEpsilonGreedyStrategy(math.NaN(), 0, 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+	_, err = services.NewEpsilonGreedyStrategy(0.1, math.NaN(), 1)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+	_, err = services.NewStrategy(config.RotationConfig{Strategy: services.DecayingEpsilonGreedyStrategyName})
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+
+	t.Run("zero epsilon always exploits", func(t *testing.T) {
+		t.Parallel()
+		testStats := fakeStatsSliceWithEmptyStats(10)
+		for ind := range testStats {
+			testStats[ind].ShowAmount.Int64 = 100
+			testStats[ind].ClickAmount.Int64 = int64(ind)
+		}
+		strategy, err := services.NewEpsilonGreedyStrategy(0, 0, 42)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			require.Equal(t, testStats[9].BannerID, strategy.NextBannerID(context.Background(), testStats))
+		}
+	})
+
+	t.Run("not shown banners are shown first", func(t *testing.T) {
+		t.Parallel()
+		testStats := fakeStatsSliceWithEmptyStats(2)
+		testStats[0].ShowAmount.Int64 = 10
+		testStats[0].ClickAmount.Int64 = 10
+		strategy, err := services.NewEpsilonGreedyStrategy(0, 0, 42)
+		require.NoError(t, err)
+		require.Equal(t, testStats[1].BannerID, strategy.NextBannerID(context.Background(), testStats))
+	})
+
+	t.Run("exploration share is close to epsilon", func(t *testing.T) {
+		t.Parallel()
+		testStats := fakeStatsSliceWithEmptyStats(10)
+		for ind := range testStats {
+			testStats[ind].ShowAmount.Int64 = 100
+		}
+		testStats[0].ClickAmount.Int64 = 50
+		fixed, err := services.NewEpsilonGreedyStrategy(0.5, 0, 42)
+		require.NoError(t, err)
+		decaying, err := services.NewEpsilonGreedyStrategy(0.5, 0.01, 42)
+		require.NoError(t, err)
+
+		fixedExplorations, decayingExplorations := 0, 0
+		for i := 0; i < 10000; i++ {
+			if fixed.NextBannerID(context.Background(), testStats) != testStats[0].BannerID {
+				fixedExplorations++
+			}
+			if decaying.NextBannerID(context.Background(), testStats) != testStats[0].BannerID {
+				decayingExplorations++
+			}
+		}
+		// random choice hits the best banner in 1 of 10 explorations
+		require.InDelta(t, 0.5*0.9, float64(fixedExplorations)/10000, 0.03)
+		require.Less(t, decayingExplorations, fixedExplorations)
+	})
+}
+
+func TestSlidingWindowUCB1Strategy(t *testing.T) {
+	t.Parallel()
+
+	_, err := services.NewSlidingWindowUCB1Strategy(services.NewUCB1Strategy(), 0)
+	require.True(t, errors.Is(err, services.ErrInvalidStrategyParams))
+
+	strategy, err := services.NewStrategy(config.RotationConfig{
+		Strategy: services.SlidingWindowUCB1StrategyName,
+		Window:   time.Hour,
+	})
+	require.NoError(t, err)
+	windowed, ok := strategy.(services.WindowedStrategy)
+	require.True(t, ok)
+	require.Equal(t, time.Hour, windowed.Window())
+}
+
+func TestRankBanners(t *testing.T) {
+	t.Parallel()
+
+	testStats := fakeStatsSlice()
+	ranked := services.RankBanners(context.Background(), firstBannerStrategy{}, testStats, 3)
+	require.Equal(t, []string{testStats[0].BannerID, testStats[1].BannerID, testStats[2].BannerID}, ranked)
+
+	strategy, err := services.NewThompsonStrategy(1, 1, 42)
+	require.NoError(t, err)
+	ranked = services.RankBanners(context.Background(), strategy, testStats, len(testStats)+10)
+	require.Len(t, ranked, len(testStats))
+	unique := make(map[string]struct{}, len(ranked))
+	for _, id := range ranked {
+		unique[id] = struct{}{}
+	}
+	require.Len(t, unique, len(testStats))
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+const ThompsonStrategyName = "thompson"
+
+var _ Strategy = (*ThompsonStrategy)(nil)
+
+// ThompsonStrategy is a Strategy based on Thompson sampling with Beta-Bernoulli model.
+// On each step for every banner value is sampled from Beta(clicks + alpha, shows - clicks + beta)
+// distribution and banner with max sampled value is chosen.
+// Theory can be found in paper below:
+// Chapelle O., Li L. An Empirical Evaluation of Thompson Sampling, NIPS 2011.
+type ThompsonStrategy struct {
+	alpha float64
+	beta  float64
+	rnd   *lockedRand
+}
+
+// NewThompsonStrategy creates Thompson sampling strategy with Beta(alpha, beta) prior.
+// Zero seed means that random generator is seeded with the current time.
+func NewThompsonStrategy(alpha, beta float64, seed int64) (*ThompsonStrategy, error) {
+	if math.IsNaN(alpha) || math.IsNaN(beta) || alpha <= 0 || beta <= 0 {
+		return nil, fmt.Errorf("%w: thompson alpha and beta must be positive, got alpha=%v beta=%v",
+			ErrInvalidStrategyParams, alpha, beta)
+	}
+	return &ThompsonStrategy{
+		alpha: alpha,
+		beta:  beta,
+		rnd:   newLockedRand(seed),
+	}, nil
+}
+
+func newThompsonStrategy(cnf config.RotationConfig) (Strategy, error) {
+	return NewThompsonStrategy(cnf.ThompsonAlpha, cnf.ThompsonBeta, cnf.Seed)
+}
+
+func (t *ThompsonStrategy) NextBannerID(_ context.Context, bannerStats []storage.SlotBannerStat) string {
+	maxSample := -1.0
+	maxBannerID := bannerStats[0].BannerID
+	for _, bannerStat := range bannerStats {
+		clicks, shows := float64(bannerStat.GetClicks()), float64(bannerStat.GetShows())
+		// clicks amount can't be more than shows amount, but stats can be inconsistent
+		failures := math.Max(shows-clicks, 0)
+		sample := t.rnd.beta(clicks+t.alpha, failures+t.beta)
+		if sample > maxSample {
+			maxSample = sample
+			maxBannerID = bannerStat.BannerID
+		}
+	}
+	return maxBannerID
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+const (
+	UCB1StrategyName              = "ucb1"
+	SlidingWindowUCB1StrategyName = "sliding-window-ucb1"
+
+	// DefaultExplorationConstant is an exploration constant of the original UCB1 algo.
+	DefaultExplorationConstant = 2.0
+)
+
+var (
+	_ Strategy         = UCB1Strategy{}
+	_ WindowedStrategy = SlidingWindowUCB1Strategy{}
+)
+
+// UCB1Strategy is a Strategy based on UCB1 algo for a multiarmed bandit problem
+// Al the theory behind the scenes can be found in paper below:
+// DOI:10.1023/A:1013689704352, Authors: Auer et al., 2002.
+// Original link: https://link.springer.com/content/pdf/10.1023/A:1013689704352.pdf.
+type UCB1Strategy struct {
+	explorationConstant float64
+}
+
+func NewUCB1Strategy() UCB1Strategy {
+	return UCB1Strategy{explorationConstant: DefaultExplorationConstant}
+}
+
+// NewUCB1StrategyWithExploration creates UCB1 strategy with custom exploration constant,
+// the bigger constant is, the more often banners with few shows are chosen.
+func NewUCB1StrategyWithExploration(explorationConstant float64) (UCB1Strategy, error) {
+	if math.IsNaN(explorationConstant) || explorationConstant <= 0 {
+		return UCB1Strategy{}, fmt.Errorf("%w: exploration constant must be positive, got %v",
+			ErrInvalidStrategyParams, explorationConstant)
+	}
+	return UCB1Strategy{explorationConstant: explorationConstant}, nil
+}
+
+// newUCB1Strategy creates UCB1 strategy from config, zero exploration constant means default one.
+func newUCB1Strategy(cnf config.RotationConfig) (UCB1Strategy, error) {
+	if cnf.ExplorationConstant == 0 {
+		return NewUCB1Strategy(), nil
+	}
+	return NewUCB1StrategyWithExploration(cnf.ExplorationConstant)
+}
+
+// NextBannerID - evaluates (using UCB1 algo) next banner id using banner shows and clicks stats.
+func (u UCB1Strategy) NextBannerID(_ context.Context, bannerStats []storage.SlotBannerStat) string {
+	// all available banners should be shown at least once
+	if bannerID, ok := findNotShownBanner(bannerStats); ok {
+		return bannerID
+	}
+
+	// show banner which has max targetFunction value
+	totalBannerShows := countTotalShowsAmount(bannerStats)
+	maxTargetValue := 0.0
+	maxBannerID := bannerStats[0].BannerID
+	for _, bannerStat := range bannerStats {
+		bannerClicks, bannerShows := bannerStat.GetClicks(), bannerStat.GetShows()
+		targetValue := targetFunction(
+			float64(bannerClicks),
+			float64(bannerShows),
+			float64(totalBannerShows),
+			u.explorationConstant,
+		)
+		if big.NewFloat(targetValue).Cmp(big.NewFloat(maxTargetValue)) > 0 {
+			maxTargetValue = targetValue
+			maxBannerID = bannerStat.BannerID
+		}
+	}
+	return maxBannerID
+}
+
+// SlidingWindowUCB1Strategy is a UCB1 strategy which takes into account only recent banner stats,
+// so banners which were popular long ago don't win forever when user preferences change.
+// The idea is described in paper below:
+// Garivier A., Moulines E. On Upper-Confidence Bound Policies for Non-Stationary Bandit Problems, 2008.
+// Original link: https://arxiv.org/abs/0805.3415.
+type SlidingWindowUCB1Strategy struct {
+	UCB1Strategy
+	window time.Duration
+}
+
+func NewSlidingWindowUCB1Strategy(ucb1 UCB1Strategy, window time.Duration) (SlidingWindowUCB1Strategy, error) {
+	if window <= 0 {
+		return SlidingWindowUCB1Strategy{}, fmt.Errorf("%w: window must be positive, got %v",
+			ErrInvalidStrategyParams, window)
+	}
+	return SlidingWindowUCB1Strategy{UCB1Strategy: ucb1, window: window}, nil
+}
+
+func newSlidingWindowUCB1Strategy(cnf config.RotationConfig) (Strategy, error) {
+	ucb1, err := newUCB1Strategy(cnf)
+	if err != nil {
+		return nil, err
+	}
+	return NewSlidingWindowUCB1Strategy(ucb1, cnf.Window)
+}
+
+// Window returns duration of the time window for which banner stats are taken into account.
+// Stats are stored in hour buckets, so window is effectively rounded up to the whole hours.
+func (s SlidingWindowUCB1Strategy) Window() time.Duration {
+	return s.window
+}
+
+// findNotShownBanner returns id of the first banner which has never been shown.
+func findNotShownBanner(bannerStats []storage.SlotBannerStat) (string, bool) {
+	for _, bannerStat := range bannerStats {
+		if bannerStat.GetShows() == 0 {
+			return bannerStat.BannerID, true
+		}
+	}
+	return "", false
+}
+
+func countTotalShowsAmount(stats []storage.SlotBannerStat) int64 {
+	var totalShows int64
+	for _, stat := range stats {
+		totalShows += stat.GetShows()
+	}
+	return totalShows
+}
+
+// targetFunction is a maximizing on each step in UCB1 algo function value
+// it should be used to evaluate value for each banner.
+func targetFunction(clickCount, showCount, totalShowCount, explorationConstant float64) float64 {
+	avgBannerIncome := clickCount / showCount
+	return avgBannerIncome + math.Sqrt((explorationConstant*math.Log(totalShowCount))/showCount)
+}
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"go.uber.org/zap"
+)
+
+type slotGroupKey struct {
+	slotID  string
+	groupID string
+}
+
+type pendingKey struct {
+	bannerID    string
+	bucketStart time.Time
+}
+
+// maxFlushBackoff is the longest delay of the next flush after repeated flush failures.
+const maxFlushBackoff = time.Minute
+
+// WriteBehindRepository is a Repository which buffers shows and clicks in memory
+// and writes them to the underlying repository in batches (see config.WriteBehindConfig).
+// Buffered stats are added to stats read through it, so rotation in this service instance
+// takes into account not flushed shows and clicks too. Reads don't wait for the running flush,
+// stats being flushed are added to them too, so a read racing with the flush commit may count them twice.
+// Clicks are accepted without banner show check, clicks of not shown banners are skipped on flush.
+type WriteBehindRepository struct {
+	Repository
+	maxPending int
+	interval   time.Duration
+	flushCh    chan struct{}
+	mu         sync.Mutex
+	pending    map[slotGroupKey]map[pendingKey]*storage.StatsDelta
+	size       int
+	// flushing are stats which are being written, clicks of their banners wait for the flush
+	flushing  map[slotGroupKey]map[pendingKey]*storage.StatsDelta
+	slotLocks *slotLocks
+}
+
+func NewWriteBehindRepository(repo Repository, cnf config.WriteBehindConfig) *WriteBehindRepository {
+	return &WriteBehindRepository{
+		Repository: repo,
+		maxPending: cnf.MaxPending,
+		interval:   cnf.FlushInterval,
+		flushCh:    make(chan struct{}, 1),
+		pending:    make(map[slotGroupKey]map[pendingKey]*storage.StatsDelta),
+		slotLocks:  newSlotLocks(),
+	}
+}
+
+// Run flushes buffered stats every flush interval or when buffer is full until context is done.
+// After failed flush the next one is delayed, delay is doubled on each failure up to maxFlushBackoff.
+// Stats buffered after Run is finished should be flushed with Flush.
+func (w *WriteBehindRepository) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	var (
+		backoff   time.Duration
+		nextFlush time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.flushCh:
+		}
+		if time.Now().Before(nextFlush) {
+			continue
+		}
+		if err := w.Flush(ctx); err != nil {
+			backoff = nextFlushBackoff(backoff, w.interval)
+			nextFlush = time.Now().Add(backoff)
+			zap.L().Error("failed to flush buffered stats, will retry later",
+				zap.Duration("retryIn", backoff), zap.Error(err))
+			continue
+		}
+		backoff = 0
+	}
+}
+
+func nextFlushBackoff(backoff, interval time.Duration) time.Duration {
+	if backoff == 0 {
+		return interval
+	}
+	if backoff*2 > maxFlushBackoff {
+		return maxFlushBackoff
+	}
+	return backoff * 2
+}
+
+// Flush writes all buffered stats to the underlying repository in one batch.
+// Buffer is swapped before the write, so stats are read and buffered during it.
+// If write fails, stats are returned to the buffer while it has less than max pending increments,
+// the rest of them are dropped.
+func (w *WriteBehindRepository) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	pending := w.pending
+	w.flushing = pending
+	w.pending = make(map[slotGroupKey]map[pendingKey]*storage.StatsDelta)
+	w.size = 0
+	w.mu.Unlock()
+
+	var deltas []storage.StatsDelta
+	for _, bannerDeltas := range pending {
+		for _, delta := range bannerDeltas {
+			deltas = append(deltas, *delta)
+		}
+	}
+	var err error
+	if len(deltas) > 0 {
+		err = w.Repository.PersistStats(ctx, deltas)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushing = nil
+	if err != nil {
+		w.restore(deltas)
+		return fmt.Errorf("failed to persist %d buffered stats: %w", len(deltas), err)
+	}
+	return nil
+}
+
+// restore returns not flushed increments to the buffer, increments which don't fit to max pending are dropped.
+// Should be called with mu held.
+func (w *WriteBehindRepository) restore(deltas []storage.StatsDelta) {
+	var dropped, droppedClicks, droppedShows int64
+	for _, delta := range deltas {
+		if !w.merge(delta, true) {
+			dropped++
+			droppedClicks += delta.Clicks
+			droppedShows += delta.Shows
+		}
+	}
+	if dropped > 0 {
+		zap.L().Error("buffered stats don't fit to the buffer after failed flush and are dropped",
+			zap.Int64("increments", dropped),
+			zap.Int64("clicks", droppedClicks),
+			zap.Int64("shows", droppedShows))
+	}
+}
+
+// add adds increment to the buffer and requests flush if buffer is full.
+func (w *WriteBehindRepository) add(delta storage.StatsDelta) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.merge(delta, false)
+	if w.size >= w.maxPending {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// merge adds increment to the buffered one of the same banner and hour or buffers it as a new one.
+// If capped, new increment isn't buffered when buffer has max pending increments and false is returned.
+// Should be called with mu held.
+func (w *WriteBehindRepository) merge(delta storage.StatsDelta, capped bool) bool {
+	sgKey := slotGroupKey{slotID: delta.SlotID, groupID: delta.GroupID}
+	key := pendingKey{bannerID: delta.BannerID, bucketStart: delta.BucketStart}
+	if buffered, ok := w.pending[sgKey][key]; ok {
+		buffered.Clicks += delta.Clicks
+		buffered.Shows += delta.Shows
+		return true
+	}
+	if capped && w.size >= w.maxPending {
+		return false
+	}
+	bannerDeltas, ok := w.pending[sgKey]
+	if !ok {
+		bannerDeltas = make(map[pendingKey]*storage.StatsDelta)
+		w.pending[sgKey] = bannerDeltas
+	}
+	bannerDeltas[key] = &delta
+	w.size++
+	return true
+}
+
+func (w *WriteBehindRepository) addNow(slotID, groupID, bannerID string, clicks, shows int64) {
+	w.add(storage.StatsDelta{
+		SlotID:      slotID,
+		GroupID:     groupID,
+		BannerID:    bannerID,
+		BucketStart: time.Now().Truncate(time.Hour),
+		Clicks:      clicks,
+		Shows:       shows,
+	})
+}
+
+func (w *WriteBehindRepository) PersistClick(_ context.Context, slotID, groupID, bannerID string) error {
+	w.addNow(slotID, groupID, bannerID, 1, 0)
+	return nil
+}
+
+func (w *WriteBehindRepository) PersistShow(_ context.Context, slotID, groupID, bannerID string) error {
+	w.addNow(slotID, groupID, bannerID, 0, 1)
+	return nil
+}
+
+func (w *WriteBehindRepository) PersistShows(_ context.Context, slotID, groupID string, bannerIDs []string) error {
+	for _, bannerID := range bannerIDs {
+		w.addNow(slotID, groupID, bannerID, 0, 1)
+	}
+	return nil
+}
+
+func (w *WriteBehindRepository) PersistSlotsShows(_ context.Context, groupID string, shows []storage.SlotBanner) error {
+	for _, show := range shows {
+		w.addNow(show.SlotID, groupID, show.BannerID, 0, 1)
+	}
+	return nil
+}
+
+// PersistClickOnce flushes buffered shows of the banner before the click,
+// because underlying repository checks that the banner was shown.
+//
+//nolint:lll
+func (w *WriteBehindRepository) PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error {
+	if err := w.flushIfPending(ctx, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	return w.Repository.PersistClickOnce(ctx, idempotencyKey, slotID, groupID, bannerID, window)
+}
+
+// PersistImpressionClick flushes buffered shows of the banner before the click,
+// because underlying repository checks that the banner was shown.
+//
+//nolint:lll
+func (w *WriteBehindRepository) PersistImpressionClick(ctx context.Context, impressionID, slotID, groupID, bannerID string) error {
+	if err := w.flushIfPending(ctx, slotID, groupID, bannerID); err != nil {
+		return err
+	}
+	return w.Repository.PersistImpressionClick(ctx, impressionID, slotID, groupID, bannerID)
+}
+
+func (w *WriteBehindRepository) flushIfPending(ctx context.Context, slotID, groupID, bannerID string) error {
+	sgKey := slotGroupKey{slotID: slotID, groupID: groupID}
+	pending := false
+	w.mu.Lock()
+	for _, bannerDeltas := range []map[pendingKey]*storage.StatsDelta{w.pending[sgKey], w.flushing[sgKey]} {
+		for key := range bannerDeltas {
+			if key.bannerID == bannerID {
+				pending = true
+			}
+		}
+	}
+	w.mu.Unlock()
+	if !pending {
+		return nil
+	}
+	return w.Flush(ctx)
+}
+
+//nolint:lll
+func (w *WriteBehindRepository) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	stats, err := w.Repository.FindSlotBannerStats(ctx, slotID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	w.addPending(stats, slotID, groupID, time.Time{})
+	return stats, nil
+}
+
+//nolint:lll
+func (w *WriteBehindRepository) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	stats, err := w.Repository.FindSlotBannerStatsSince(ctx, slotID, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+	w.addPending(stats, slotID, groupID, since)
+	return stats, nil
+}
+
+//nolint:lll
+func (w *WriteBehindRepository) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	stats, err := w.Repository.FindSlotsBannerStats(ctx, slotIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	for slotID, slotStats := range stats {
+		w.addPending(slotStats, slotID, groupID, time.Time{})
+	}
+	return stats, nil
+}
+
+//nolint:lll
+func (w *WriteBehindRepository) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	stats, err := w.Repository.FindSlotsBannerStatsSince(ctx, slotIDs, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+	for slotID, slotStats := range stats {
+		w.addPending(slotStats, slotID, groupID, since)
+	}
+	return stats, nil
+}
+
+// addPending adds buffered and being flushed increments collected since the provided time
+// to the stats read from repository.
+func (w *WriteBehindRepository) addPending(stats []storage.SlotBannerStat, slotID, groupID string, since time.Time) {
+	since = since.Truncate(time.Hour)
+	sgKey := slotGroupKey{slotID: slotID, groupID: groupID}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, bannerDeltas := range []map[pendingKey]*storage.StatsDelta{w.pending[sgKey], w.flushing[sgKey]} {
+		for key, delta := range bannerDeltas {
+			if key.bucketStart.Before(since) {
+				continue
+			}
+			for i := range stats {
+				if stats[i].BannerID != key.bannerID {
+					continue
+				}
+				stats[i].ClickAmount = sql.NullInt64{Int64: stats[i].GetClicks() + delta.Clicks, Valid: true}
+				stats[i].ShowAmount = sql.NullInt64{Int64: stats[i].GetShows() + delta.Shows, Valid: true}
+			}
+		}
+	}
+}
+
+// InStatsTx executes fn in the unit of work of the underlying repository,
+// shows stored by fn are buffered only after the unit of work is finished successfully.
+// Slots which stats are read by fn stay locked in this service instance until the shows are buffered,
+// so concurrent units of work in the same slot don't choose banner by the same stats.
+func (w *WriteBehindRepository) InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error {
+	locked := make(map[string]struct{})
+	defer func() {
+		for slotID := range locked {
+			w.slotLocks.unlock(slotID)
+		}
+	}()
+
+	var tx *writeBehindTx
+	if err := w.Repository.InStatsTx(ctx, func(repoTx storage.StatsTx) error {
+		// unit of work may be retried, so shows of the previous attempts are dropped
+		tx = &writeBehindTx{w: w, tx: repoTx, locked: locked}
+		return fn(tx)
+	}); err != nil {
+		return err
+	}
+	for _, show := range tx.shows {
+		w.add(show)
+	}
+	return nil
+}
+
+// writeBehindTx reads stats in the unit of work of the underlying repository and collects shows.
+type writeBehindTx struct {
+	w      *WriteBehindRepository
+	tx     storage.StatsTx
+	shows  []storage.StatsDelta
+	locked map[string]struct{}
+}
+
+// lock locks the slots in id order in this service instance, slots locked by the previous attempts stay locked.
+func (t *writeBehindTx) lock(slotIDs ...string) {
+	sorted := append([]string(nil), slotIDs...)
+	sort.Strings(sorted)
+	for _, slotID := range sorted {
+		if _, ok := t.locked[slotID]; ok {
+			continue
+		}
+		t.w.slotLocks.lock(slotID)
+		t.locked[slotID] = struct{}{}
+	}
+}
+
+//nolint:lll
+func (t *writeBehindTx) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	t.lock(slotID)
+	stats, err := t.tx.FindSlotBannerStats(ctx, slotID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	t.w.addPending(stats, slotID, groupID, time.Time{})
+	return stats, nil
+}
+
+//nolint:lll
+func (t *writeBehindTx) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	t.lock(slotID)
+	stats, err := t.tx.FindSlotBannerStatsSince(ctx, slotID, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+	t.w.addPending(stats, slotID, groupID, since)
+	return stats, nil
+}
+
+func (t *writeBehindTx) PersistShow(_ context.Context, slotID, groupID, bannerID string) error {
+	t.shows = append(t.shows, storage.StatsDelta{
+		SlotID:      slotID,
+		GroupID:     groupID,
+		BannerID:    bannerID,
+		BucketStart: time.Now().Truncate(time.Hour),
+		Shows:       1,
+	})
+	return nil
+}
+
+func (t *writeBehindTx) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	for _, bannerID := range bannerIDs {
+		if err := t.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:lll
+func (t *writeBehindTx) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	t.lock(slotIDs...)
+	stats, err := t.tx.FindSlotsBannerStats(ctx, slotIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	for slotID, slotStats := range stats {
+		t.w.addPending(slotStats, slotID, groupID, time.Time{})
+	}
+	return stats, nil
+}
+
+//nolint:lll
+func (t *writeBehindTx) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	t.lock(slotIDs...)
+	stats, err := t.tx.FindSlotsBannerStatsSince(ctx, slotIDs, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+	for slotID, slotStats := range stats {
+		t.w.addPending(slotStats, slotID, groupID, since)
+	}
+	return stats, nil
+}
+
+func (t *writeBehindTx) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	for _, show := range shows {
+		if err := t.PersistShow(ctx, show.SlotID, groupID, show.BannerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slotLocks are in-process locks of the slots, lock of the slot is removed when nobody holds or waits for it.
+type slotLocks struct {
+	mu    sync.Mutex
+	locks map[string]*slotLock
+}
+
+type slotLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newSlotLocks() *slotLocks {
+	return &slotLocks{locks: make(map[string]*slotLock)}
+}
+
+func (l *slotLocks) lock(slotID string) {
+	l.mu.Lock()
+	lock, ok := l.locks[slotID]
+	if !ok {
+		lock = &slotLock{}
+		l.locks[slotID] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+	lock.mu.Lock()
+}
+
+func (l *slotLocks) unlock(slotID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock := l.locks[slotID]
+	lock.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, slotID)
+	}
+}
@@ -0,0 +1,255 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/services"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/memory"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:lll
+func newWriteBehindSlotBanner(ctx context.Context, t *testing.T, repo *memory.Storage) (slotID, groupID, bannerID string) {
+	t.Helper()
+	slotID, err := repo.AddSlot(ctx, "slot")
+	require.NoError(t, err)
+	bannerID, err = repo.AddBanner(ctx, "banner")
+	require.NoError(t, err)
+	groupID, err = repo.AddGroup(ctx, "group")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddBannerToSlot(ctx, slotID, bannerID))
+	return slotID, groupID, bannerID
+}
+
+func TestWriteBehindBuffersStats(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewStorage()
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo)
+	writeBehind := services.NewWriteBehindRepository(repo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    100,
+	})
+
+	require.NoError(t, writeBehind.PersistShow(ctx, slotID, groupID, bannerID))
+	require.NoError(t, writeBehind.PersistShows(ctx, slotID, groupID, []string{bannerID, bannerID}))
+	require.NoError(t, writeBehind.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		return tx.PersistShow(ctx, slotID, groupID, bannerID)
+	}))
+	require.NoError(t, writeBehind.PersistClick(ctx, slotID, groupID, bannerID))
+
+	// stats are not written yet, but visible through the write-behind repository
+	stats, err := repo.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), stats[0].GetShows())
+	stats, err = writeBehind.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats[0].GetShows())
+	require.Equal(t, int64(1), stats[0].GetClicks())
+
+	require.NoError(t, writeBehind.Flush(ctx))
+	stats, err = repo.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats[0].GetShows())
+	require.Equal(t, int64(1), stats[0].GetClicks())
+	stats, err = writeBehind.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats[0].GetShows())
+}
+
+func TestWriteBehindFlushesShowsBeforeClickOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewStorage()
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo)
+	writeBehind := services.NewWriteBehindRepository(repo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    100,
+	})
+
+	require.NoError(t, writeBehind.PersistShow(ctx, slotID, groupID, bannerID))
+	require.NoError(t, writeBehind.PersistClickOnce(ctx, "key", slotID, groupID, bannerID, time.Minute))
+	stats, err := repo.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats[0].GetShows())
+	require.Equal(t, int64(1), stats[0].GetClicks())
+}
+
+func TestWriteBehindFlushOnMaxPending(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	repo := memory.NewStorage()
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo)
+	otherBannerID, err := repo.AddBanner(ctx, "other banner")
+	require.NoError(t, err)
+	require.NoError(t, repo.AddBannerToSlot(ctx, slotID, otherBannerID))
+	writeBehind := services.NewWriteBehindRepository(repo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    2,
+	})
+	go writeBehind.Run(ctx)
+
+	require.NoError(t, writeBehind.PersistShow(ctx, slotID, groupID, bannerID))
+	require.NoError(t, writeBehind.PersistShow(ctx, slotID, groupID, otherBannerID))
+	require.Eventually(t, func() bool {
+		stats, err := repo.FindSlotBannerStats(ctx, slotID, groupID)
+		require.NoError(t, err)
+		return stats[0].GetShows() == 1 && stats[1].GetShows() == 1
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestWriteBehindFlushFailure(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	writeBehind := services.NewWriteBehindRepository(mockRepo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    100,
+	})
+	require.NoError(t, writeBehind.PersistShow(ctx, "slot", "group", "banner"))
+	require.NoError(t, writeBehind.PersistShow(ctx, "slot", "group", "banner"))
+
+	// failed batch is returned to the buffer and written with the next flush
+	errTest := errors.New("test error")
+	bucketStart := time.Now().Truncate(time.Hour)
+	expectedDeltas := []storage.StatsDelta{
+		{SlotID: "slot", GroupID: "group", BannerID: "banner", BucketStart: bucketStart, Shows: 2},
+	}
+	gomock.InOrder(
+		mockRepo.EXPECT().PersistStats(ctx, expectedDeltas).Return(errTest),
+		mockRepo.EXPECT().PersistStats(ctx, expectedDeltas).Return(nil),
+	)
+	require.True(t, errors.Is(writeBehind.Flush(ctx), errTest))
+	require.NoError(t, writeBehind.Flush(ctx))
+	// nothing left to write
+	require.NoError(t, writeBehind.Flush(ctx))
+}
+
+func TestWriteBehindFlushFailureDropsOverflow(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockRepository(ctl)
+	writeBehind := services.NewWriteBehindRepository(mockRepo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    2,
+	})
+	for _, bannerID := range []string{"first", "second", "third"} {
+		require.NoError(t, writeBehind.PersistShow(ctx, "slot", "group", bannerID))
+	}
+
+	// only max pending increments of the failed batch are returned to the buffer
+	errTest := errors.New("test error")
+	gomock.InOrder(
+		mockRepo.EXPECT().PersistStats(ctx, gomock.Len(3)).Return(errTest),
+		mockRepo.EXPECT().PersistStats(ctx, gomock.Len(2)).Return(nil),
+	)
+	require.True(t, errors.Is(writeBehind.Flush(ctx), errTest))
+	require.NoError(t, writeBehind.Flush(ctx))
+}
+
+// blockedFlushRepo waits for the release before buffered stats are written.
+type blockedFlushRepo struct {
+	*memory.Storage
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r blockedFlushRepo) PersistStats(ctx context.Context, deltas []storage.StatsDelta) error {
+	close(r.started)
+	<-r.release
+	return r.Storage.PersistStats(ctx, deltas)
+}
+
+// TestWriteBehindReadDuringFlush checks that stats are read without waiting for the flush
+// and stats being flushed are added to them.
+func TestWriteBehindReadDuringFlush(t *testing.T) {
+	ctx := context.Background()
+	repo := blockedFlushRepo{
+		Storage: memory.NewStorage(),
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo.Storage)
+	writeBehind := services.NewWriteBehindRepository(repo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    100,
+	})
+	require.NoError(t, writeBehind.PersistShow(ctx, slotID, groupID, bannerID))
+
+	flushed := make(chan error)
+	go func() {
+		flushed <- writeBehind.Flush(ctx)
+	}()
+	<-repo.started
+	stats, err := writeBehind.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats[0].GetShows())
+	require.NoError(t, writeBehind.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		stats, err := tx.FindSlotBannerStats(ctx, slotID, groupID)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, int64(1), stats[0].GetShows())
+		return tx.PersistShow(ctx, slotID, groupID, bannerID)
+	}))
+
+	close(repo.release)
+	require.NoError(t, <-flushed)
+	stats, err = writeBehind.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats[0].GetShows())
+}
+
+// slowTxRepo pauses after the unit of work is finished.
+type slowTxRepo struct {
+	*memory.Storage
+}
+
+func (r slowTxRepo) InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error {
+	err := r.Storage.InStatsTx(ctx, fn)
+	time.Sleep(time.Millisecond)
+	return err
+}
+
+// TestWriteBehindStatsTxIsolation checks that concurrent units of work never read the same stats,
+// though their shows are buffered after the underlying unit of work is finished.
+func TestWriteBehindStatsTxIsolation(t *testing.T) {
+	ctx := context.Background()
+	repo := slowTxRepo{Storage: memory.NewStorage()}
+	slotID, groupID, bannerID := newWriteBehindSlotBanner(ctx, t, repo.Storage)
+	writeBehind := services.NewWriteBehindRepository(repo, config.WriteBehindConfig{
+		FlushInterval: time.Hour,
+		MaxPending:    1000,
+	})
+
+	const workers = 50
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	seenShows := make(map[int64]struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shows int64
+			err := writeBehind.InStatsTx(ctx, func(tx storage.StatsTx) error {
+				stats, err := tx.FindSlotBannerStats(ctx, slotID, groupID)
+				if err != nil {
+					return err
+				}
+				shows = stats[0].GetShows()
+				return tx.PersistShow(ctx, slotID, groupID, bannerID)
+			})
+			require.NoError(t, err)
+			mu.Lock()
+			seenShows[shows] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seenShows, workers)
+}
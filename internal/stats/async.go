@@ -0,0 +1,334 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"go.uber.org/zap"
+)
+
+// Policies for messages published when the queue of AsyncPublisher is full.
+const (
+	// OverflowDrop rejects message with ErrQueueFull.
+	OverflowDrop = "drop"
+	// OverflowBlock waits until there is a space in the queue.
+	OverflowBlock = "block"
+	// OverflowSpill appends message to the spill file, spilled messages are put back to the queue later.
+	OverflowSpill = "spill"
+)
+
+const spillFileName = "stats-spill.jsonl"
+
+var (
+	ErrQueueFull        = errors.New("stats publisher queue is full")
+	ErrPublisherStopped = errors.New("stats publisher is stopped")
+	ErrUnknownOverflow  = errors.New("unknown stats publisher queue overflow policy")
+)
+
+// MessagePublisher publishes stats messages synchronously.
+type MessagePublisher interface {
+	Publish(msg Message) error
+}
+
+// ConfirmingPublisher reports messages confirmed by rabbit to the handler set with OnConfirm.
+type ConfirmingPublisher interface {
+	MessagePublisher
+	OnConfirm(handler func(eventID string))
+}
+
+// AsyncPublisherMetrics are counters of the messages which weren't put to the queue of AsyncPublisher.
+type AsyncPublisherMetrics struct {
+	Queued  int    `json:"queued"`
+	Dropped uint64 `json:"dropped"`
+	Spilled uint64 `json:"spilled"`
+}
+
+// AsyncPublisher puts messages to the bounded queue and publishes them by the background workers,
+// so publishing doesn't wait for rabbit. Failed publishing is retried until the publisher is stopped.
+// On stop the queue is drained during the drain timeout, messages which are not published by then
+// are spilled to the disk with spill overflow policy and are lost with other policies.
+type AsyncPublisher struct {
+	publisher     MessagePublisher
+	overflow      string
+	workers       int
+	retryInterval time.Duration
+	drainTimeout  time.Duration
+	spillPath     string
+
+	mu        sync.RWMutex
+	closed    bool
+	queue     chan Message
+	stopping  chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	restoreWg sync.WaitGroup
+
+	spillMu sync.Mutex
+	dropped uint64
+	spilled uint64
+
+	confirmMu sync.RWMutex
+	onConfirm func(eventID string)
+}
+
+func NewAsyncPublisher(publisher MessagePublisher, cnf config.AsyncPublisherConfig) (*AsyncPublisher, error) {
+	p := &AsyncPublisher{
+		publisher:     publisher,
+		overflow:      cnf.Overflow,
+		workers:       cnf.Workers,
+		retryInterval: cnf.RetryInterval,
+		drainTimeout:  cnf.DrainTimeout,
+		queue:         make(chan Message, cnf.QueueSize),
+		stopping:      make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if p.workers < 1 {
+		p.workers = 1
+	}
+	switch cnf.Overflow {
+	case OverflowDrop, OverflowBlock:
+	case OverflowSpill:
+		if err := os.MkdirAll(cnf.SpillDir, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create spill directory: %w", err)
+		}
+		p.spillPath = filepath.Join(cnf.SpillDir, spillFileName)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOverflow, cnf.Overflow)
+	}
+	return p, nil
+}
+
+// Start runs the workers and, with spill overflow policy, putting spilled messages back to the queue.
+// Messages spilled before the previous stop are published too.
+func (p *AsyncPublisher) Start() {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for msg := range p.queue {
+				p.publish(msg)
+			}
+		}()
+	}
+	if p.overflow == OverflowSpill {
+		p.restoreWg.Add(1)
+		go func() {
+			defer p.restoreWg.Done()
+			p.restoreSpilled()
+		}()
+	}
+}
+
+// Publish puts message to the queue, if the queue is full message is handled by the overflow policy.
+func (p *AsyncPublisher) Publish(msg Message) error {
+	// id is generated once, so retried message has the same event id
+	msg, err := withID(msg)
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPublisherStopped
+	}
+	select {
+	case p.queue <- msg:
+		return nil
+	default:
+	}
+
+	switch p.overflow {
+	case OverflowBlock:
+		select {
+		case p.queue <- msg:
+			return nil
+		case <-p.stopping:
+			return ErrPublisherStopped
+		}
+	case OverflowSpill:
+		return p.spill([]Message{msg})
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+		return ErrQueueFull
+	}
+}
+
+// publish publishes message with retries until it succeeds or the drain timeout is over.
+func (p *AsyncPublisher) publish(msg Message) {
+	for {
+		err := p.publisher.Publish(msg)
+		if err == nil {
+			p.published(msg)
+			return
+		}
+		select {
+		case <-p.done:
+			p.lose(msg, err)
+			return
+		case <-time.After(p.retryInterval):
+		}
+	}
+}
+
+// OnConfirm sets handler which is called with the event id of every published message.
+// If the wrapped publisher confirms messages, handler is passed to it and is called on confirmation,
+// otherwise handler is called when the wrapped publisher returns without error.
+func (p *AsyncPublisher) OnConfirm(handler func(eventID string)) {
+	if confirming, ok := p.publisher.(ConfirmingPublisher); ok {
+		confirming.OnConfirm(handler)
+		return
+	}
+	p.confirmMu.Lock()
+	defer p.confirmMu.Unlock()
+	p.onConfirm = handler
+}
+
+func (p *AsyncPublisher) published(msg Message) {
+	p.confirmMu.RLock()
+	defer p.confirmMu.RUnlock()
+	if p.onConfirm != nil {
+		p.onConfirm(msg.ID)
+	}
+}
+
+// lose spills message which wasn't published before stop or drops it if spilling isn't enabled.
+func (p *AsyncPublisher) lose(msg Message, err error) {
+	if p.overflow == OverflowSpill {
+		if spillErr := p.spill([]Message{msg}); spillErr == nil {
+			return
+		}
+	}
+	atomic.AddUint64(&p.dropped, 1)
+	zap.L().Error("stats message wasn't published before stop and is lost",
+		zap.String("eventID", msg.ID), zap.Error(err))
+}
+
+// spill appends messages to the spill file as json lines.
+func (p *AsyncPublisher) spill(msgs []Message) error {
+	var data []byte
+	for _, msg := range msgs {
+		line, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("error during marshalling spilled message: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	p.spillMu.Lock()
+	defer p.spillMu.Unlock()
+	file, err := os.OpenFile(p.spillPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open spill file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close spill file: %w", err)
+	}
+	atomic.AddUint64(&p.spilled, uint64(len(msgs)))
+	return nil
+}
+
+// takeSpilled reads and removes the spill file.
+func (p *AsyncPublisher) takeSpilled() ([]Message, error) {
+	p.spillMu.Lock()
+	defer p.spillMu.Unlock()
+	data, err := os.ReadFile(p.spillPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spill file: %w", err)
+	}
+	if err := os.Remove(p.spillPath); err != nil {
+		return nil, fmt.Errorf("failed to remove spill file: %w", err)
+	}
+
+	var msgs []Message
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var msg Message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			zap.L().Error("spilled stats message is corrupted and skipped", zap.Error(err))
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// restoreSpilled puts spilled messages back to the queue every retry interval until the publisher is stopping.
+func (p *AsyncPublisher) restoreSpilled() {
+	ticker := time.NewTicker(p.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stopping:
+			return
+		case <-ticker.C:
+		}
+		msgs, err := p.takeSpilled()
+		if err != nil {
+			zap.L().Error("failed to restore spilled stats messages", zap.Error(err))
+			continue
+		}
+		for i, msg := range msgs {
+			select {
+			case p.queue <- msg:
+				continue
+			case <-p.stopping:
+			}
+			// messages which aren't put back before stop are kept on the disk
+			if err := p.spill(msgs[i:]); err != nil {
+				zap.L().Error("failed to spill back stats messages, they are lost",
+					zap.Int("count", len(msgs)-i), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// Stop stops accepting messages and waits until the queue is drained or the drain timeout is over.
+func (p *AsyncPublisher) Stop() {
+	close(p.stopping)
+	p.restoreWg.Wait()
+	p.mu.Lock()
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(p.drainTimeout):
+		zap.L().Warn("stats publisher queue isn't drained before timeout", zap.Int("queued", len(p.queue)))
+		close(p.done)
+		<-drained
+	}
+}
+
+// Metrics returns amount of queued messages and counters of the messages which weren't queued.
+func (p *AsyncPublisher) Metrics() AsyncPublisherMetrics {
+	return AsyncPublisherMetrics{
+		Queued:  len(p.queue),
+		Dropped: atomic.LoadUint64(&p.dropped),
+		Spilled: atomic.LoadUint64(&p.spilled),
+	}
+}
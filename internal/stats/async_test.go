@@ -0,0 +1,207 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher records published messages, publishing waits for the gate if it's set.
+type fakePublisher struct {
+	mu        sync.Mutex
+	gate      chan struct{}
+	err       error
+	published []Message
+}
+
+func (f *fakePublisher) Publish(msg Message) error {
+	if f.gate != nil {
+		<-f.gate
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.published = append(f.published, msg)
+	return nil
+}
+
+func (f *fakePublisher) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.published)
+}
+
+func asyncConfig(overflow string, dir string) config.AsyncPublisherConfig {
+	return config.AsyncPublisherConfig{
+		QueueSize:     1,
+		Workers:       1,
+		Overflow:      overflow,
+		SpillDir:      dir,
+		RetryInterval: time.Millisecond * 10,
+		DrainTimeout:  time.Second,
+	}
+}
+
+// fillQueue publishes two messages with the single worker blocked on the first one, so the queue is full.
+func fillQueue(t *testing.T, p *AsyncPublisher, fake *fakePublisher) {
+	t.Helper()
+	require.NoError(t, p.Publish(Message{BannerID: "b1"}))
+	require.Eventually(t, func() bool { return len(p.queue) == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, p.Publish(Message{BannerID: "b2"}))
+}
+
+func TestAsyncPublisherDrainsOnStop(t *testing.T) {
+	fake := &fakePublisher{}
+	cnf := asyncConfig(OverflowDrop, "")
+	cnf.QueueSize = 100
+	p, err := NewAsyncPublisher(fake, cnf)
+	require.NoError(t, err)
+	p.Start()
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, p.Publish(Message{BannerID: "b"}))
+	}
+	p.Stop()
+	require.Equal(t, 50, fake.count())
+	for _, msg := range fake.published {
+		require.NotEmpty(t, msg.ID)
+	}
+	require.ErrorIs(t, p.Publish(Message{BannerID: "b"}), ErrPublisherStopped)
+}
+
+func TestAsyncPublisherConfirmsPublished(t *testing.T) {
+	fake := &fakePublisher{err: errors.New("rabbit is down")}
+	p, err := NewAsyncPublisher(fake, asyncConfig(OverflowDrop, ""))
+	require.NoError(t, err)
+	confirmed := make(chan string, 1)
+	p.OnConfirm(func(eventID string) {
+		confirmed <- eventID
+	})
+	p.Start()
+	defer p.Stop()
+
+	require.NoError(t, p.Publish(Message{ID: "event"}))
+	// message isn't confirmed until it's published
+	time.Sleep(time.Millisecond * 30)
+	require.Empty(t, confirmed)
+	fake.mu.Lock()
+	fake.err = nil
+	fake.mu.Unlock()
+	require.Equal(t, "event", <-confirmed)
+}
+
+func TestAsyncPublisherRetriesFailedPublishing(t *testing.T) {
+	fake := &fakePublisher{err: ErrNotConnected}
+	p, err := NewAsyncPublisher(fake, asyncConfig(OverflowDrop, ""))
+	require.NoError(t, err)
+	p.Start()
+
+	require.NoError(t, p.Publish(Message{BannerID: "b"}))
+	time.Sleep(time.Millisecond * 30)
+	fake.mu.Lock()
+	fake.err = nil
+	fake.mu.Unlock()
+	require.Eventually(t, func() bool { return fake.count() == 1 }, time.Second, time.Millisecond)
+	p.Stop()
+}
+
+func TestAsyncPublisherDropOverflow(t *testing.T) {
+	fake := &fakePublisher{gate: make(chan struct{})}
+	p, err := NewAsyncPublisher(fake, asyncConfig(OverflowDrop, ""))
+	require.NoError(t, err)
+	p.Start()
+
+	fillQueue(t, p, fake)
+	require.ErrorIs(t, p.Publish(Message{BannerID: "b3"}), ErrQueueFull)
+	require.Equal(t, uint64(1), p.Metrics().Dropped)
+
+	close(fake.gate)
+	p.Stop()
+	require.Equal(t, 2, fake.count())
+}
+
+func TestAsyncPublisherBlockOverflow(t *testing.T) {
+	fake := &fakePublisher{gate: make(chan struct{})}
+	p, err := NewAsyncPublisher(fake, asyncConfig(OverflowBlock, ""))
+	require.NoError(t, err)
+	p.Start()
+
+	fillQueue(t, p, fake)
+	published := make(chan error)
+	go func() {
+		published <- p.Publish(Message{BannerID: "b3"})
+	}()
+	select {
+	case <-published:
+		require.Fail(t, "publishing to the full queue isn't blocked")
+	case <-time.After(time.Millisecond * 30):
+	}
+
+	close(fake.gate)
+	require.NoError(t, <-published)
+	p.Stop()
+	require.Equal(t, 3, fake.count())
+}
+
+func TestAsyncPublisherSpillOverflow(t *testing.T) {
+	dir := t.TempDir()
+	fake := &fakePublisher{gate: make(chan struct{})}
+	p, err := NewAsyncPublisher(fake, asyncConfig(OverflowSpill, dir))
+	require.NoError(t, err)
+	p.Start()
+
+	fillQueue(t, p, fake)
+	require.NoError(t, p.Publish(Message{BannerID: "b3"}))
+	require.Equal(t, uint64(1), p.Metrics().Spilled)
+	require.FileExists(t, filepath.Join(dir, spillFileName))
+
+	close(fake.gate)
+	require.Eventually(t, func() bool { return fake.count() == 3 }, time.Second, time.Millisecond)
+	p.Stop()
+	_, err = os.Stat(filepath.Join(dir, spillFileName))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestAsyncPublisherSpillsNotPublishedOnStop(t *testing.T) {
+	dir := t.TempDir()
+	fake := &fakePublisher{err: ErrNotConnected}
+	cnf := asyncConfig(OverflowSpill, dir)
+	cnf.QueueSize = 10
+	cnf.DrainTimeout = time.Millisecond * 50
+	p, err := NewAsyncPublisher(fake, cnf)
+	require.NoError(t, err)
+	p.Start()
+	for _, bannerID := range []string{"b1", "b2", "b3"} {
+		require.NoError(t, p.Publish(Message{BannerID: bannerID}))
+	}
+	p.Stop()
+	require.Equal(t, 0, fake.count())
+	require.FileExists(t, filepath.Join(dir, spillFileName))
+
+	// messages spilled on stop are published after restart
+	restarted := &fakePublisher{}
+	p, err = NewAsyncPublisher(restarted, cnf)
+	require.NoError(t, err)
+	p.Start()
+	require.Eventually(t, func() bool { return restarted.count() == 3 }, time.Second, time.Millisecond)
+	p.Stop()
+
+	var bannerIDs []string
+	for _, msg := range restarted.published {
+		bannerIDs = append(bannerIDs, msg.BannerID)
+	}
+	require.ElementsMatch(t, []string{"b1", "b2", "b3"}, bannerIDs)
+}
+
+func TestNewAsyncPublisherUnknownOverflow(t *testing.T) {
+	_, err := NewAsyncPublisher(&fakePublisher{}, asyncConfig("unknown", ""))
+	require.ErrorIs(t, err, ErrUnknownOverflow)
+}
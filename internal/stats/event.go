@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+// EventSchemaVersion is a version of the event published in the message body.
+const EventSchemaVersion = 1
+
+// Event is a published body of the click or show message.
+type Event struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"event_id"`
+	Type          string    `json:"type"`
+	Timestamp     time.Time `json:"timestamp"`
+	SlotID        string    `json:"slot_id"`
+	BannerID      string    `json:"banner_id"`
+	GroupID       string    `json:"group_id"`
+}
+
+// legacyEvent is a body of messages published before the event schema was versioned,
+// type and timestamp are sent only in the message properties.
+type legacyEvent struct {
+	BannerID string `json:"bannerId"`
+	SlotID   string `json:"slotId"`
+	GroupID  string `json:"groupId"`
+}
+
+// EncodeMessage returns message body in the current event schema or, if legacy is set, in the old unversioned format.
+func EncodeMessage(msg Message, legacy bool) ([]byte, error) {
+	var event interface{}
+	if legacy {
+		event = legacyEvent{BannerID: msg.BannerID, SlotID: msg.SlotID, GroupID: msg.GroupID}
+	} else {
+		event = Event{
+			SchemaVersion: EventSchemaVersion,
+			ID:            msg.ID,
+			Type:          msg.Type,
+			Timestamp:     msg.Timestamp.UTC(),
+			SlotID:        msg.SlotID,
+			BannerID:      msg.BannerID,
+			GroupID:       msg.GroupID,
+		}
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("error during marhalling message data: %w", err)
+	}
+	return data, nil
+}
+
+// withID returns message with the generated id if it doesn't have one.
+func withID(msg Message) (Message, error) {
+	if msg.ID != "" {
+		return msg, nil
+	}
+	id, err := storage.NewID()
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to generate event id: %w", err)
+	}
+	msg.ID = id
+	return msg, nil
+}
@@ -0,0 +1,40 @@
+package stats_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/stats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMessage(t *testing.T) {
+	msg := stats.Message{
+		ID:        "event",
+		BannerID:  "banner",
+		SlotID:    "slot",
+		GroupID:   "group",
+		Type:      "click",
+		Timestamp: time.Date(2021, 8, 12, 10, 30, 0, 0, time.FixedZone("MSK", 3*60*60)),
+	}
+
+	data, err := stats.EncodeMessage(msg, false)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"schema_version": 1,
+		"event_id": "event",
+		"type": "click",
+		"timestamp": "2021-08-12T07:30:00Z",
+		"slot_id": "slot",
+		"banner_id": "banner",
+		"group_id": "group"
+	}`, string(data))
+	var event stats.Event
+	require.NoError(t, json.Unmarshal(data, &event))
+	require.True(t, event.Timestamp.Equal(msg.Timestamp))
+
+	data, err = stats.EncodeMessage(msg, true)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"bannerId": "banner", "slotId": "slot", "groupId": "group"}`, string(data))
+}
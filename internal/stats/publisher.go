@@ -1,8 +1,11 @@
 package stats
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Raschudesny/otus_project/v1/internal/config"
@@ -10,21 +13,141 @@ import (
 	"go.uber.org/zap"
 )
 
-const PublisherMsgKey = "amqp.rotation.service.key"
+const (
+	PublisherMsgKey = "amqp.rotation.service.key"
 
+	confirmsBufferSize = 256
+	// stopConfirmTimeout is a time during which publisher waits for confirmations of published messages on stop.
+	stopConfirmTimeout = time.Second * 5
+)
+
+var ErrNotConnected = errors.New("stats publisher is not connected to rabbit server")
+
+// Message is a click or show event, it's published with the body encoded by EncodeMessage.
+// Empty ID is generated on publishing.
 type Message struct {
-	BannerID  string    `json:"bannerId"`
-	SlotID    string    `json:"slotId"`
-	GroupID   string    `json:"groupId"`
-	Type      string    `json:"-"`
-	Timestamp time.Time `json:"-"`
+	ID        string
+	BannerID  string
+	SlotID    string
+	GroupID   string
+	Type      string
+	Timestamp time.Time
+}
+
+// PublisherMetrics are counters of the messages published since the publisher creation.
+type PublisherMetrics struct {
+	Published uint64 `json:"published"`
+	Confirmed uint64 `json:"confirmed"`
+	Nacked    uint64 `json:"nacked"`
+	Retried   uint64 `json:"retried"`
+	Dropped   uint64 `json:"dropped"`
+	// RetryDropped is an amount of not confirmed messages dropped because the retry queue was full.
+	RetryDropped uint64 `json:"retryDropped"`
+	// Unconfirmed is an amount of published messages which are not confirmed yet, including waiting for retry.
+	Unconfirmed int64 `json:"unconfirmed"`
+}
+
+// outgoing is a message published to rabbit.
+type outgoing struct {
+	msg      amqp.Publishing
+	attempts int
+}
+
+// confirmTracker keeps messages published to the channel in confirm mode until they are confirmed.
+type confirmTracker struct {
+	mu      sync.Mutex
+	lastTag uint64
+	pending map[uint64]*outgoing
+}
+
+func newConfirmTracker() *confirmTracker {
+	return &confirmTracker{pending: make(map[uint64]*outgoing)}
+}
+
+// add stores message with the next delivery tag of the channel.
+func (t *confirmTracker) add(out *outgoing) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastTag++
+	t.pending[t.lastTag] = out
+	return t.lastTag
+}
+
+// cancel removes the last added message which was not published.
+func (t *confirmTracker) cancel(tag uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, tag)
+	t.lastTag--
+}
+
+func (t *confirmTracker) remove(tag uint64) (*outgoing, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out, ok := t.pending[tag]
+	delete(t.pending, tag)
+	return out, ok
+}
+
+func (t *confirmTracker) size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+// drain removes all not confirmed messages and returns them in the publishing order.
+func (t *confirmTracker) drain() []*outgoing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tags := make([]uint64, 0, len(t.pending))
+	for tag := range t.pending {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	result := make([]*outgoing, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, t.pending[tag])
+	}
+	t.pending = make(map[uint64]*outgoing)
+	return result
 }
 
+// Publisher publishes stats messages to rabbit with at-least-once delivery.
+// Channel is used in confirm mode, messages nacked by rabbit or not confirmed before connection loss
+// are published again, so consumers should deduplicate events by the event id.
+// Lost connection is restored in background, messages published while it's lost fail with ErrNotConnected.
 type Publisher struct {
-	conn         *amqp.Connection
-	ch           *amqp.Channel
-	exchangeName string
-	queueName    string
+	uri                string
+	exchangeName       string
+	queueName          string
+	legacyFormat       bool
+	reconnectDelay     time.Duration
+	maxReconnectDelay  time.Duration
+	retryInterval      time.Duration
+	maxPublishAttempts int
+	maxRetryQueue      int
+
+	mu        sync.Mutex
+	conn      *amqp.Connection
+	ch        *amqp.Channel
+	tracker   *confirmTracker
+	done      chan struct{}
+	wg        sync.WaitGroup
+	confirmWg sync.WaitGroup
+
+	retryMu sync.Mutex
+	retry   []*outgoing
+
+	confirmMu sync.RWMutex
+	onConfirm func(eventID string)
+
+	published    uint64
+	confirmed    uint64
+	nacked       uint64
+	retried      uint64
+	dropped      uint64
+	retryDropped uint64
+	unconfirmed  int64
 }
 
 func NewPublisher(cnf config.PublisherConfig) (*Publisher, error) {
@@ -34,18 +157,46 @@ func NewPublisher(cnf config.PublisherConfig) (*Publisher, error) {
 	}
 
 	return &Publisher{
-		conn:         connection,
-		queueName:    cnf.QueueName,
-		exchangeName: cnf.ExchangeName,
+		uri:                cnf.URI,
+		conn:               connection,
+		queueName:          cnf.QueueName,
+		exchangeName:       cnf.ExchangeName,
+		legacyFormat:       cnf.LegacyFormat,
+		reconnectDelay:     cnf.ReconnectDelay,
+		maxReconnectDelay:  cnf.MaxReconnectDelay,
+		retryInterval:      cnf.RetryInterval,
+		maxPublishAttempts: cnf.MaxPublishAttempts,
+		maxRetryQueue:      cnf.MaxRetryQueue,
+		done:               make(chan struct{}),
 	}, nil
 }
 
 func (p *Publisher) Start() error {
-	channel, err := p.conn.Channel()
+	channel, tracker, err := p.setup(p.conn)
+	if err != nil {
+		return err
+	}
+	p.ch, p.tracker = channel, tracker
+
+	p.wg.Add(2)
+	go func() {
+		defer p.wg.Done()
+		p.supervise(p.conn, channel)
+	}()
+	go func() {
+		defer p.wg.Done()
+		p.retryUnconfirmed()
+	}()
+	zap.L().Info("rotation service stats publisher successfully started")
+	return nil
+}
+
+// setup opens channel in confirm mode and declares exchange, queue and binding used for publishing.
+func (p *Publisher) setup(conn *amqp.Connection) (*amqp.Channel, *confirmTracker, error) {
+	channel, err := conn.Channel()
 	if err != nil {
-		return fmt.Errorf("failed to get channel for rabbit connection: %w", err)
+		return nil, nil, fmt.Errorf("failed to get channel for rabbit connection: %w", err)
 	}
-	p.ch = channel
 
 	if err := channel.ExchangeDeclare(
 		p.exchangeName,
@@ -56,7 +207,7 @@ func (p *Publisher) Start() error {
 		false,
 		nil,
 	); err != nil {
-		return fmt.Errorf("failed to create exchange: %w", err)
+		return nil, nil, fmt.Errorf("failed to create exchange: %w", err)
 	}
 
 	if _, err := channel.QueueDeclare(
@@ -67,7 +218,7 @@ func (p *Publisher) Start() error {
 		false,
 		nil,
 	); err != nil {
-		return fmt.Errorf(" failed to create queue: %w", err)
+		return nil, nil, fmt.Errorf(" failed to create queue: %w", err)
 	}
 
 	if err := channel.QueueBind(
@@ -77,34 +228,303 @@ func (p *Publisher) Start() error {
 		false,
 		nil,
 	); err != nil {
-		return fmt.Errorf("failed to bind queue: %w", err)
+		return nil, nil, fmt.Errorf("failed to bind queue: %w", err)
 	}
-	zap.L().Info("rotation service stats publisher successfully started")
-	return nil
+
+	if err := channel.Confirm(false); err != nil {
+		return nil, nil, fmt.Errorf("failed to put channel into confirm mode: %w", err)
+	}
+	tracker := newConfirmTracker()
+	confirms := channel.NotifyPublish(make(chan amqp.Confirmation, confirmsBufferSize))
+	p.confirmWg.Add(1)
+	go func() {
+		defer p.confirmWg.Done()
+		p.handleConfirms(tracker, confirms)
+	}()
+	return channel, tracker, nil
+}
+
+// handleConfirms processes confirmations of the channel until it's closed.
+// Nacked messages and messages which are not confirmed before channel close are scheduled for retry.
+func (p *Publisher) handleConfirms(tracker *confirmTracker, confirms <-chan amqp.Confirmation) {
+	for confirm := range confirms {
+		out, ok := tracker.remove(confirm.DeliveryTag)
+		if !ok {
+			continue
+		}
+		if confirm.Ack {
+			atomic.AddUint64(&p.confirmed, 1)
+			atomic.AddInt64(&p.unconfirmed, -1)
+			p.confirmMu.RLock()
+			if p.onConfirm != nil {
+				p.onConfirm(out.msg.MessageId)
+			}
+			p.confirmMu.RUnlock()
+			continue
+		}
+		atomic.AddUint64(&p.nacked, 1)
+		p.scheduleRetry(out)
+	}
+	for _, out := range tracker.drain() {
+		p.scheduleRetry(out)
+	}
+}
+
+// OnConfirm sets handler which is called with the event id of every message confirmed by rabbit,
+// handler is called from the confirmations goroutine, so it shouldn't block.
+func (p *Publisher) OnConfirm(handler func(eventID string)) {
+	p.confirmMu.Lock()
+	defer p.confirmMu.Unlock()
+	p.onConfirm = handler
+}
+
+// scheduleRetry adds message to the retry queue or drops it if publishing attempts are exhausted.
+func (p *Publisher) scheduleRetry(out *outgoing) {
+	if out.attempts >= p.maxPublishAttempts {
+		atomic.AddUint64(&p.dropped, 1)
+		atomic.AddInt64(&p.unconfirmed, -1)
+		zap.L().Error("stats event was not confirmed by rabbit and is dropped",
+			zap.String("eventID", out.msg.MessageId), zap.Int("attempts", out.attempts))
+		return
+	}
+	p.retryMu.Lock()
+	defer p.retryMu.Unlock()
+	p.retry = append(p.retry, out)
+	p.trimRetryLocked()
 }
 
+// trimRetryLocked drops messages which don't fit into the retry queue, the latest ones are dropped first.
+// Dropped messages stay not confirmed, so outbox relay publishes them again after the lease.
+func (p *Publisher) trimRetryLocked() {
+	overflow := len(p.retry) - p.maxRetryQueue
+	if overflow <= 0 {
+		return
+	}
+	p.retry = p.retry[:p.maxRetryQueue]
+	atomic.AddUint64(&p.retryDropped, uint64(overflow))
+	atomic.AddInt64(&p.unconfirmed, -int64(overflow))
+	zap.L().Error("stats events were not confirmed by rabbit and are dropped, retry queue is full",
+		zap.Int("count", overflow), zap.Int("maxRetryQueue", p.maxRetryQueue))
+}
+
+// retryUnconfirmed publishes again messages from the retry queue every retry interval until publisher is stopped.
+func (p *Publisher) retryUnconfirmed() {
+	ticker := time.NewTicker(p.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+		p.republish()
+	}
+}
+
+func (p *Publisher) republish() {
+	p.retryMu.Lock()
+	retry := p.retry
+	p.retry = nil
+	p.retryMu.Unlock()
+	if len(retry) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, out := range retry {
+		if err := p.publishLocked(out); err != nil {
+			// not published messages are retried on the next retry
+			p.retryMu.Lock()
+			p.retry = append(retry[i:], p.retry...)
+			p.trimRetryLocked()
+			p.retryMu.Unlock()
+			zap.L().Warn("failed to publish again not confirmed stats events",
+				zap.Int("count", len(retry)-i), zap.Error(err))
+			return
+		}
+		atomic.AddUint64(&p.retried, 1)
+	}
+}
+
+// supervise waits for the connection or channel close and reconnects until publisher is stopped.
+func (p *Publisher) supervise(conn *amqp.Connection, ch *amqp.Channel) {
+	for {
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+		var closeErr *amqp.Error
+		select {
+		case <-p.done:
+			return
+		case closeErr = <-connClosed:
+		case closeErr = <-chClosed:
+		}
+		if closeErr != nil {
+			zap.L().Error("rabbit connection lost, reconnecting", zap.Error(closeErr))
+		} else {
+			zap.L().Error("rabbit connection closed, reconnecting")
+		}
+
+		p.mu.Lock()
+		p.ch, p.tracker = nil, nil
+		p.mu.Unlock()
+		// channel could be closed by server with alive connection, so connection is recreated anyway
+		if err := conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			zap.L().Error("error during lost rabbit connection closing", zap.Error(err))
+		}
+
+		var ok bool
+		if conn, ch, ok = p.reconnect(); !ok {
+			return
+		}
+		zap.L().Info("rabbit connection restored")
+	}
+}
+
+// reconnect dials rabbit with exponential backoff until it succeeds or publisher is stopped.
+func (p *Publisher) reconnect() (*amqp.Connection, *amqp.Channel, bool) {
+	delay := p.reconnectDelay
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-p.done:
+			return nil, nil, false
+		case <-time.After(delay):
+		}
+
+		conn, ch, tracker, err := p.connect()
+		if err == nil {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			select {
+			case <-p.done:
+				// publisher was stopped during reconnection
+				if err := conn.Close(); err != nil {
+					zap.L().Error("error during rabbit connection closing", zap.Error(err))
+				}
+				return nil, nil, false
+			default:
+			}
+			p.conn, p.ch, p.tracker = conn, ch, tracker
+			return conn, ch, true
+		}
+		zap.L().Error("failed to reconnect to rabbit",
+			zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(err))
+		delay = nextReconnectDelay(delay, p.maxReconnectDelay)
+	}
+}
+
+func (p *Publisher) connect() (*amqp.Connection, *amqp.Channel, *confirmTracker, error) {
+	conn, err := amqp.Dial(p.uri)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to rabbit server: %w", err)
+	}
+	ch, tracker, err := p.setup(conn)
+	if err != nil {
+		if err := conn.Close(); err != nil {
+			zap.L().Error("error during rabbit connection closing", zap.Error(err))
+		}
+		return nil, nil, nil, err
+	}
+	return conn, ch, tracker, nil
+}
+
+// nextReconnectDelay doubles delay up to the max delay.
+func nextReconnectDelay(delay, maxDelay time.Duration) time.Duration {
+	delay *= 2
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// Stop waits for confirmations of the published messages during stopConfirmTimeout and closes connection.
+// Messages which are not confirmed at this moment are lost.
 func (p *Publisher) Stop() error {
-	return p.conn.Close()
+	p.mu.Lock()
+	close(p.done)
+	conn, tracker := p.conn, p.tracker
+	p.ch, p.tracker = nil, nil
+	p.mu.Unlock()
+	p.wg.Wait()
+
+	if tracker != nil {
+		deadline := time.Now().Add(stopConfirmTimeout)
+		for tracker.size() > 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond * 10)
+		}
+	}
+
+	err := conn.Close()
+	p.confirmWg.Wait()
+	if metrics := p.Metrics(); metrics.Unconfirmed > 0 {
+		zap.L().Error("stats events were not confirmed by rabbit before stop and are lost",
+			zap.Int64("count", metrics.Unconfirmed))
+	}
+	if err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("error during rabbit connection closing: %w", err)
+	}
+	return nil
+}
+
+// Metrics returns counters of the published messages.
+func (p *Publisher) Metrics() PublisherMetrics {
+	return PublisherMetrics{
+		Published:    atomic.LoadUint64(&p.published),
+		Confirmed:    atomic.LoadUint64(&p.confirmed),
+		Nacked:       atomic.LoadUint64(&p.nacked),
+		Retried:      atomic.LoadUint64(&p.retried),
+		Dropped:      atomic.LoadUint64(&p.dropped),
+		RetryDropped: atomic.LoadUint64(&p.retryDropped),
+		Unconfirmed:  atomic.LoadInt64(&p.unconfirmed),
+	}
 }
 
 func (p *Publisher) Publish(msg Message) error {
-	data, err := json.Marshal(msg)
+	msg, err := withID(msg)
 	if err != nil {
-		return fmt.Errorf("error during marhalling message data: %w", err)
+		return err
+	}
+	data, err := EncodeMessage(msg, p.legacyFormat)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// message can be confirmed before publishLocked returns, so it's counted in advance
+	atomic.AddInt64(&p.unconfirmed, 1)
+	if err := p.publishLocked(&outgoing{msg: amqp.Publishing{
+		ContentType: "application/json",
+		MessageId:   msg.ID,
+		Timestamp:   msg.Timestamp,
+		Type:        msg.Type,
+		AppId:       "banner-rotation",
+		Body:        data,
+	}}); err != nil {
+		atomic.AddInt64(&p.unconfirmed, -1)
+		return err
+	}
+	atomic.AddUint64(&p.published, 1)
+	return nil
+}
+
+// publishLocked publishes message to the current channel and tracks it until confirmation, p.mu must be held.
+func (p *Publisher) publishLocked(out *outgoing) error {
+	if p.ch == nil {
+		return ErrNotConnected
 	}
+	out.attempts++
+	// publishing is serialized by p.mu, so the tag is the same as the channel assigns to the message
+	tag := p.tracker.add(out)
 	if err := p.ch.Publish(
 		p.exchangeName,
 		PublisherMsgKey,
 		false,
 		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Timestamp:   msg.Timestamp,
-			Type:        msg.Type,
-			AppId:       "banner-rotation",
-			Body:        data,
-		},
+		out.msg,
 	); err != nil {
+		p.tracker.cancel(tag)
+		out.attempts--
 		return fmt.Errorf("error during publishing data to rabbit queue: %w", err)
 	}
 	return nil
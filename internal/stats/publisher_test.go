@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextReconnectDelay(t *testing.T) {
+	delay := time.Second
+	var delays []time.Duration
+	for i := 0; i < 6; i++ {
+		delay = nextReconnectDelay(delay, time.Second*10)
+		delays = append(delays, delay)
+	}
+	require.Equal(t, []time.Duration{
+		time.Second * 2,
+		time.Second * 4,
+		time.Second * 8,
+		time.Second * 10,
+		time.Second * 10,
+		time.Second * 10,
+	}, delays)
+}
+
+func TestHandleConfirms(t *testing.T) {
+	p := &Publisher{maxPublishAttempts: 2, maxRetryQueue: 10}
+	tracker := newConfirmTracker()
+	outs := []*outgoing{
+		{msg: amqp.Publishing{MessageId: "1"}, attempts: 1},
+		{msg: amqp.Publishing{MessageId: "2"}, attempts: 1},
+		{msg: amqp.Publishing{MessageId: "3"}, attempts: 2},
+		{msg: amqp.Publishing{MessageId: "4"}, attempts: 1},
+	}
+	for _, out := range outs {
+		tracker.add(out)
+	}
+	p.unconfirmed = int64(len(outs))
+	var confirmedIDs []string
+	p.OnConfirm(func(eventID string) {
+		confirmedIDs = append(confirmedIDs, eventID)
+	})
+
+	confirms := make(chan amqp.Confirmation, 2)
+	confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+	confirms <- amqp.Confirmation{DeliveryTag: 3, Ack: false}
+	close(confirms)
+	p.handleConfirms(tracker, confirms)
+
+	// 3rd message is nacked and its attempts are exhausted, others are not confirmed before channel close
+	require.Equal(t, PublisherMetrics{Confirmed: 1, Nacked: 1, Dropped: 1, Unconfirmed: 2}, p.Metrics())
+	require.Equal(t, []*outgoing{outs[1], outs[3]}, p.retry)
+	require.Equal(t, []string{"1"}, confirmedIDs)
+	require.Zero(t, tracker.size())
+}
+
+func TestScheduleRetryQueueBounded(t *testing.T) {
+	p := &Publisher{maxPublishAttempts: 2, maxRetryQueue: 2}
+	outs := []*outgoing{
+		{msg: amqp.Publishing{MessageId: "1"}, attempts: 1},
+		{msg: amqp.Publishing{MessageId: "2"}, attempts: 1},
+		{msg: amqp.Publishing{MessageId: "3"}, attempts: 1},
+	}
+	p.unconfirmed = int64(len(outs))
+	for _, out := range outs {
+		p.scheduleRetry(out)
+	}
+
+	// message which doesn't fit into the retry queue is dropped
+	require.Equal(t, []*outgoing{outs[0], outs[1]}, p.retry)
+	require.Equal(t, PublisherMetrics{RetryDropped: 1, Unconfirmed: 2}, p.Metrics())
+}
+
+func TestConfirmTrackerCancel(t *testing.T) {
+	tracker := newConfirmTracker()
+	require.Equal(t, uint64(1), tracker.add(&outgoing{}))
+	tag := tracker.add(&outgoing{})
+	tracker.cancel(tag)
+	require.Equal(t, tag, tracker.add(&outgoing{}))
+	require.Equal(t, 2, tracker.size())
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+)
+
+// NewID generates random (version 4) uuid for ids which aren't generated by the database.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error during id generation: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// IsValidID checks that id is a uuid in the canonical form, e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+func IsValidID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
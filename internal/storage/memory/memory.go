@@ -0,0 +1,849 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+)
+
+// statKey identifies banner stats of the slot for a social group.
+type statKey struct {
+	slotID   string
+	groupID  string
+	bannerID string
+}
+
+// impressionClick is a counted click of the impression.
+type impressionClick struct {
+	key       statKey
+	clickedAt time.Time
+}
+
+type counter struct {
+	clicks int64
+	shows  int64
+}
+
+// outboxEntry is a stats event with its publishing state.
+type outboxEntry struct {
+	event       storage.OutboxEvent
+	lockedUntil time.Time
+	sent        bool
+	sentAt      time.Time
+}
+
+// Storage is a thread-safe in-memory repository with the same semantics as sql storage,
+// it's useful for local runs and tests, all data is lost on service stop.
+type Storage struct {
+	mu               sync.RWMutex
+	now              func() time.Time
+	slots            map[string]storage.Slot
+	banners          map[string]storage.Banner
+	groups           map[string]storage.SocialGroup
+	slotBanners      map[string][]string
+	settings         map[string]storage.SlotSettings
+	stats            map[statKey]*counter
+	buckets          map[statKey]map[time.Time]*counter
+	impressionClicks map[string]impressionClick
+	clickKeys        map[string]time.Time
+	outbox           []*outboxEntry
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		now:              time.Now,
+		slots:            make(map[string]storage.Slot),
+		banners:          make(map[string]storage.Banner),
+		groups:           make(map[string]storage.SocialGroup),
+		slotBanners:      make(map[string][]string),
+		settings:         make(map[string]storage.SlotSettings),
+		stats:            make(map[statKey]*counter),
+		buckets:          make(map[statKey]map[time.Time]*counter),
+		impressionClicks: make(map[string]impressionClick),
+		clickKeys:        make(map[string]time.Time),
+	}
+}
+
+func (s *Storage) Connect(_ context.Context) error {
+	return nil
+}
+
+func (s *Storage) Close() error {
+	return nil
+}
+
+func (s *Storage) AddSlot(_ context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots[id] = storage.Slot{ID: id, Description: description}
+	return id, nil
+}
+
+func (s *Storage) GetSlotByID(_ context.Context, id string) (storage.Slot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	slot, ok := s.slots[id]
+	if !ok {
+		return storage.Slot{}, storage.ErrSlotNotFound
+	}
+	return slot, nil
+}
+
+// UpdateSlot updates description of the slot.
+func (s *Storage) UpdateSlot(_ context.Context, slot storage.Slot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.slots[slot.ID]; !ok {
+		return storage.ErrSlotNotFound
+	}
+	s.slots[slot.ID] = slot
+	return nil
+}
+
+// DeleteSlot removes the slot with its banners relations, settings and stats.
+func (s *Storage) DeleteSlot(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.slots[id]; !ok {
+		return storage.ErrSlotNotFound
+	}
+	delete(s.slots, id)
+	delete(s.slotBanners, id)
+	delete(s.settings, id)
+	s.deleteStats(func(key statKey) bool { return key.slotID == id })
+	return nil
+}
+
+func (s *Storage) GetSlotSettings(_ context.Context, slotID string) (storage.SlotSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.slots[slotID]; !ok {
+		return storage.SlotSettings{}, storage.ErrSlotNotFound
+	}
+	return s.slotSettings(slotID), nil
+}
+
+// GetSlotsSettings returns settings of all existing slots from the provided list.
+func (s *Storage) GetSlotsSettings(_ context.Context, slotIDs []string) ([]storage.SlotSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	settings := make([]storage.SlotSettings, 0, len(slotIDs))
+	for _, slotID := range slotIDs {
+		if _, ok := s.slots[slotID]; ok {
+			settings = append(settings, s.slotSettings(slotID))
+		}
+	}
+	return settings, nil
+}
+
+func (s *Storage) slotSettings(slotID string) storage.SlotSettings {
+	settings, ok := s.settings[slotID]
+	if !ok {
+		return storage.SlotSettings{SlotID: slotID}
+	}
+	return settings
+}
+
+func (s *Storage) SetSlotSettings(_ context.Context, settings storage.SlotSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.slots[settings.SlotID]; !ok {
+		return storage.ErrSlotNotFound
+	}
+	s.settings[settings.SlotID] = settings
+	return nil
+}
+
+func (s *Storage) AddBanner(_ context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.banners[id] = storage.Banner{ID: id, Description: description}
+	return id, nil
+}
+
+func (s *Storage) GetBannerByID(_ context.Context, id string) (storage.Banner, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	banner, ok := s.banners[id]
+	if !ok {
+		return storage.Banner{}, storage.ErrBannerNotFound
+	}
+	return banner, nil
+}
+
+// UpdateBanner updates description of the banner.
+func (s *Storage) UpdateBanner(_ context.Context, banner storage.Banner) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.banners[banner.ID]; !ok {
+		return storage.ErrBannerNotFound
+	}
+	s.banners[banner.ID] = banner
+	return nil
+}
+
+// DeleteBanner removes the banner with its slots relations and stats.
+func (s *Storage) DeleteBanner(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.banners[id]; !ok {
+		return storage.ErrBannerNotFound
+	}
+	delete(s.banners, id)
+	for slotID := range s.slotBanners {
+		s.removeSlotBanner(slotID, id)
+	}
+	s.deleteStats(func(key statKey) bool { return key.bannerID == id })
+	return nil
+}
+
+func (s *Storage) AddBannerToSlot(_ context.Context, slotID, bannerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.slots[slotID]; !ok {
+		return storage.ErrSlotNotFound
+	}
+	if _, ok := s.banners[bannerID]; !ok {
+		return storage.ErrBannerNotFound
+	}
+	for _, id := range s.slotBanners[slotID] {
+		if id == bannerID {
+			return storage.ErrSlotToBannerRelationExists
+		}
+	}
+	s.slotBanners[slotID] = append(s.slotBanners[slotID], bannerID)
+	return nil
+}
+
+func (s *Storage) DeleteBannerFromSlot(_ context.Context, slotID, bannerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.removeSlotBanner(slotID, bannerID) {
+		return storage.ErrSlotToBannerRelationNotFound
+	}
+	return nil
+}
+
+// removeSlotBanner removes banner from the slot and reports whether it was there.
+func (s *Storage) removeSlotBanner(slotID, bannerID string) bool {
+	bannerIDs := s.slotBanners[slotID]
+	for i, id := range bannerIDs {
+		if id == bannerID {
+			s.slotBanners[slotID] = append(bannerIDs[:i:i], bannerIDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) AddGroup(_ context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[id] = storage.SocialGroup{ID: id, Description: description}
+	return id, nil
+}
+
+func (s *Storage) GetGroupByID(_ context.Context, groupID string) (storage.SocialGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	group, ok := s.groups[groupID]
+	if !ok {
+		return storage.SocialGroup{}, storage.ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// UpdateGroup updates description of the social group.
+func (s *Storage) UpdateGroup(_ context.Context, group storage.SocialGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.groups[group.ID]; !ok {
+		return storage.ErrGroupNotFound
+	}
+	s.groups[group.ID] = group
+	return nil
+}
+
+// DeleteGroup removes the social group with its stats.
+func (s *Storage) DeleteGroup(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.groups[id]; !ok {
+		return storage.ErrGroupNotFound
+	}
+	delete(s.groups, id)
+	s.deleteStats(func(key statKey) bool { return key.groupID == id })
+	return nil
+}
+
+// deleteStats removes stats, stats buckets and impression clicks matched by the filter.
+func (s *Storage) deleteStats(matches func(key statKey) bool) {
+	for key := range s.stats {
+		if matches(key) {
+			delete(s.stats, key)
+		}
+	}
+	for key := range s.buckets {
+		if matches(key) {
+			delete(s.buckets, key)
+		}
+	}
+	for id, click := range s.impressionClicks {
+		if matches(click.key) {
+			delete(s.impressionClicks, id)
+		}
+	}
+}
+
+func (s *Storage) PersistClick(_ context.Context, slotID, groupID, bannerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.persistStat(statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}, 1, 0)
+}
+
+// PersistImpressionClick stores click of the impression, only the first click of each impression is counted.
+//
+//nolint:lll
+func (s *Storage) PersistImpressionClick(_ context.Context, impressionID, slotID, groupID, bannerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.impressionClicks[impressionID]; ok {
+		return storage.ErrImpressionAlreadyClicked
+	}
+	key := statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}
+	if err := s.persistStat(key, 1, 0); err != nil {
+		return err
+	}
+	s.impressionClicks[impressionID] = impressionClick{key: key, clickedAt: s.now()}
+	return nil
+}
+
+// DeleteExpiredImpressionClicks removes impression clicks counted before the provided time.
+func (s *Storage) DeleteExpiredImpressionClicks(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for id, click := range s.impressionClicks {
+		if click.clickedAt.Before(before) {
+			delete(s.impressionClicks, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// PersistClickOnce stores click with the idempotency key,
+// repeated clicks with the same key are not counted during the dedup window.
+//
+//nolint:lll
+func (s *Storage) PersistClickOnce(_ context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if createdAt, ok := s.clickKeys[idempotencyKey]; ok && !createdAt.Before(now.Add(-window)) {
+		return storage.ErrDuplicateClick
+	}
+	if err := s.persistStat(statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}, 1, 0); err != nil {
+		return err
+	}
+	s.clickKeys[idempotencyKey] = now
+	return nil
+}
+
+// DeleteExpiredClickKeys removes idempotency keys created before the provided time.
+func (s *Storage) DeleteExpiredClickKeys(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key, createdAt := range s.clickKeys {
+		if createdAt.Before(before) {
+			delete(s.clickKeys, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *Storage) PersistShow(_ context.Context, slotID, groupID, bannerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.persistShows(slotID, groupID, []string{bannerID})
+}
+
+// PersistShows stores shows of several banners at once.
+func (s *Storage) PersistShows(_ context.Context, slotID, groupID string, bannerIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.persistShows(slotID, groupID, bannerIDs)
+}
+
+// PersistSlotsShows stores shows of banners in different slots for a group at once.
+func (s *Storage) PersistSlotsShows(_ context.Context, groupID string, shows []storage.SlotBanner) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]statKey, 0, len(shows))
+	for _, show := range shows {
+		keys = append(keys, statKey{slotID: show.SlotID, groupID: groupID, bannerID: show.BannerID})
+	}
+	return s.persistShowKeys(keys)
+}
+
+func (s *Storage) persistShows(slotID, groupID string, bannerIDs []string) error {
+	keys := make([]statKey, 0, len(bannerIDs))
+	for _, bannerID := range bannerIDs {
+		keys = append(keys, statKey{slotID: slotID, groupID: groupID, bannerID: bannerID})
+	}
+	return s.persistShowKeys(keys)
+}
+
+// persistShowKeys stores shows only if all of them are valid, like it's done in sql transaction.
+func (s *Storage) persistShowKeys(keys []statKey) error {
+	for _, key := range keys {
+		if err := s.checkStatKey(key); err != nil {
+			return err
+		}
+	}
+	for _, key := range keys {
+		if err := s.persistStat(key, 0, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStatKey checks that slot, group and banner of the stats exist.
+func (s *Storage) checkStatKey(key statKey) error {
+	if _, ok := s.slots[key.slotID]; !ok {
+		return storage.ErrSlotNotFound
+	}
+	if _, ok := s.groups[key.groupID]; !ok {
+		return storage.ErrGroupNotFound
+	}
+	if _, ok := s.banners[key.bannerID]; !ok {
+		return storage.ErrBannerNotFound
+	}
+	return nil
+}
+
+// PersistStats applies stats increments at once. Increments of deleted slots, groups or banners
+// and clicks of banners which weren't shown are skipped, so one bad increment doesn't fail the whole batch.
+func (s *Storage) PersistStats(_ context.Context, deltas []storage.StatsDelta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, delta := range deltas {
+		key := statKey{slotID: delta.SlotID, groupID: delta.GroupID, bannerID: delta.BannerID}
+		if s.checkStatKey(key) != nil {
+			continue
+		}
+		// not shown banner error is ignored here, such clicks are just skipped
+		_ = s.persistStatAt(key, delta.Clicks, delta.Shows, delta.BucketStart)
+	}
+	return nil
+}
+
+// persistStat adds clicks and shows to banner stats and to the current hour bucket.
+// Stats are created only by shows, clicks of the banner which wasn't shown yet are not counted.
+func (s *Storage) persistStat(key statKey, clicks, shows int64) error {
+	return s.persistStatAt(key, clicks, shows, s.now())
+}
+
+func (s *Storage) persistStatAt(key statKey, clicks, shows int64, at time.Time) error {
+	stat, ok := s.stats[key]
+	if !ok {
+		if shows == 0 {
+			return storage.ErrBannerNotShown
+		}
+		if err := s.checkStatKey(key); err != nil {
+			return err
+		}
+	}
+	events, err := s.newOutboxEntries(key, clicks, shows, at)
+	if err != nil {
+		return err
+	}
+	s.outbox = append(s.outbox, events...)
+	if !ok {
+		stat = &counter{}
+		s.stats[key] = stat
+	}
+	stat.clicks += clicks
+	stat.shows += shows
+
+	bucketStart := at.Truncate(time.Hour)
+	buckets, ok := s.buckets[key]
+	if !ok {
+		buckets = make(map[time.Time]*counter)
+		s.buckets[key] = buckets
+	}
+	bucket, ok := buckets[bucketStart]
+	if !ok {
+		bucket = &counter{}
+		buckets[bucketStart] = bucket
+	}
+	bucket.clicks += clicks
+	bucket.shows += shows
+	return nil
+}
+
+// newOutboxEntries creates click and show events of the stats increment made at the provided time,
+// one event per click or show.
+func (s *Storage) newOutboxEntries(key statKey, clicks, shows int64, at time.Time) ([]*outboxEntry, error) {
+	entries := make([]*outboxEntry, 0, clicks+shows)
+	for _, events := range []struct {
+		eventType string
+		amount    int64
+	}{{storage.EventTypeClick, clicks}, {storage.EventTypeShow, shows}} {
+		for i := int64(0); i < events.amount; i++ {
+			id, err := storage.NewID()
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, &outboxEntry{event: storage.OutboxEvent{
+				ID:        id,
+				Type:      events.eventType,
+				SlotID:    key.slotID,
+				GroupID:   key.groupID,
+				BannerID:  key.bannerID,
+				CreatedAt: at,
+			}})
+		}
+	}
+	return entries, nil
+}
+
+// ClaimOutboxEvents returns up to limit not sent events in the order they were stored
+// and locks them for the lease time. Events which are not marked as sent until the lease end can be claimed again.
+//
+//nolint:lll
+func (s *Storage) ClaimOutboxEvents(_ context.Context, limit int, lease time.Duration) ([]storage.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var events []storage.OutboxEvent
+	for _, entry := range s.outbox {
+		if len(events) >= limit {
+			break
+		}
+		if entry.sent || entry.lockedUntil.After(now) {
+			continue
+		}
+		entry.lockedUntil = now.Add(lease)
+		events = append(events, entry.event)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventsSent marks published events as sent.
+func (s *Storage) MarkOutboxEventsSent(_ context.Context, ids []string) error {
+	sent := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		sent[id] = struct{}{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.outbox {
+		if _, ok := sent[entry.event.ID]; ok && !entry.sent {
+			entry.sent = true
+			entry.sentAt = s.now()
+		}
+	}
+	return nil
+}
+
+// DeleteSentOutboxEvents removes events sent before the provided time.
+func (s *Storage) DeleteSentOutboxEvents(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	outbox := s.outbox[:0]
+	for _, entry := range s.outbox {
+		if entry.sent && entry.sentAt.Before(before) {
+			deleted++
+			continue
+		}
+		outbox = append(outbox, entry)
+	}
+	for i := len(outbox); i < len(s.outbox); i++ {
+		s.outbox[i] = nil
+	}
+	s.outbox = outbox
+	return deleted, nil
+}
+
+func (s *Storage) FindSlotBannerStats(_ context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findSlotBannerStats(slotID, groupID), nil
+}
+
+// FindSlotBannerStatsSince returns stats of the slot banners for a group collected since the provided time.
+// Stats are stored in hour buckets, so the bucket which contains since time is included.
+//
+//nolint:lll
+func (s *Storage) FindSlotBannerStatsSince(_ context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findSlotBannerStatsSince(slotID, groupID, since), nil
+}
+
+// ListSlots returns up to limit slots with id greater than afterID ordered by id.
+func (s *Storage) ListSlots(_ context.Context, afterID string, limit int) ([]storage.Slot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.slots))
+	for id := range s.slots {
+		ids = append(ids, id)
+	}
+	var slots []storage.Slot
+	for _, id := range pageIDs(ids, afterID, limit) {
+		slots = append(slots, s.slots[id])
+	}
+	return slots, nil
+}
+
+// ListBanners returns up to limit banners with id greater than afterID ordered by id.
+func (s *Storage) ListBanners(_ context.Context, afterID string, limit int) ([]storage.Banner, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.banners))
+	for id := range s.banners {
+		ids = append(ids, id)
+	}
+	var banners []storage.Banner
+	for _, id := range pageIDs(ids, afterID, limit) {
+		banners = append(banners, s.banners[id])
+	}
+	return banners, nil
+}
+
+// ListGroups returns up to limit social groups with id greater than afterID ordered by id.
+func (s *Storage) ListGroups(_ context.Context, afterID string, limit int) ([]storage.SocialGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.groups))
+	for id := range s.groups {
+		ids = append(ids, id)
+	}
+	var groups []storage.SocialGroup
+	for _, id := range pageIDs(ids, afterID, limit) {
+		groups = append(groups, s.groups[id])
+	}
+	return groups, nil
+}
+
+// pageIDs sorts ids and returns up to limit of them which are greater than afterID.
+func pageIDs(ids []string, afterID string, limit int) []string {
+	sort.Strings(ids)
+	start := sort.Search(len(ids), func(i int) bool { return ids[i] > afterID })
+	ids = ids[start:]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// FindSlotBanners returns banners which rotate in the slot.
+func (s *Storage) FindSlotBanners(_ context.Context, slotID string) ([]storage.Banner, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var banners []storage.Banner
+	for _, bannerID := range s.slotBanners[slotID] {
+		banners = append(banners, s.banners[bannerID])
+	}
+	sort.Slice(banners, func(i, j int) bool { return banners[i].ID < banners[j].ID })
+	return banners, nil
+}
+
+// FindStatsBuckets returns hour buckets of banners stats matched by the filter ordered by time.
+//
+//nolint:lll
+func (s *Storage) FindStatsBuckets(_ context.Context, filter storage.StatsBucketsFilter) ([]storage.StatsBucket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	from := filter.From.Truncate(time.Hour)
+	var buckets []storage.StatsBucket
+	for key, keyBuckets := range s.buckets {
+		if (filter.SlotID != "" && key.slotID != filter.SlotID) ||
+			(filter.GroupID != "" && key.groupID != filter.GroupID) ||
+			(filter.BannerID != "" && key.bannerID != filter.BannerID) {
+			continue
+		}
+		for bucketStart, bucket := range keyBuckets {
+			if bucketStart.Before(from) || (!filter.To.IsZero() && !bucketStart.Before(filter.To)) {
+				continue
+			}
+			buckets = append(buckets, storage.StatsBucket{
+				SlotID:      key.slotID,
+				GroupID:     key.groupID,
+				BannerID:    key.bannerID,
+				BucketStart: bucketStart,
+				Clicks:      bucket.clicks,
+				Shows:       bucket.shows,
+			})
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		a, b := buckets[i], buckets[j]
+		switch {
+		case !a.BucketStart.Equal(b.BucketStart):
+			return a.BucketStart.Before(b.BucketStart)
+		case a.SlotID != b.SlotID:
+			return a.SlotID < b.SlotID
+		case a.GroupID != b.GroupID:
+			return a.GroupID < b.GroupID
+		default:
+			return a.BannerID < b.BannerID
+		}
+	})
+	return buckets, nil
+}
+
+// FindSlotsBannerStats returns banners stats for a group in all provided slots grouped by slot id.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStats(_ context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findSlotsBannerStats(slotIDs, groupID), nil
+}
+
+// FindSlotsBannerStatsSince returns banners stats for a group in all provided slots collected since the provided time.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStatsSince(_ context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findSlotsBannerStatsSince(slotIDs, groupID, since), nil
+}
+
+func (s *Storage) findSlotsBannerStats(slotIDs []string, groupID string) map[string][]storage.SlotBannerStat {
+	stats := make(map[string][]storage.SlotBannerStat)
+	for _, slotID := range slotIDs {
+		if slotStats := s.findSlotBannerStats(slotID, groupID); len(slotStats) > 0 {
+			stats[slotID] = slotStats
+		}
+	}
+	return stats
+}
+
+//nolint:lll
+func (s *Storage) findSlotsBannerStatsSince(slotIDs []string, groupID string, since time.Time) map[string][]storage.SlotBannerStat {
+	stats := make(map[string][]storage.SlotBannerStat)
+	for _, slotID := range slotIDs {
+		if slotStats := s.findSlotBannerStatsSince(slotID, groupID, since); len(slotStats) > 0 {
+			stats[slotID] = slotStats
+		}
+	}
+	return stats
+}
+
+func (s *Storage) findSlotBannerStats(slotID, groupID string) []storage.SlotBannerStat {
+	var stats []storage.SlotBannerStat
+	for _, bannerID := range s.slotBanners[slotID] {
+		stat := storage.SlotBannerStat{SlotID: slotID, BannerID: bannerID}
+		if c, ok := s.stats[statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}]; ok {
+			stat.ClickAmount = sql.NullInt64{Int64: c.clicks, Valid: true}
+			stat.ShowAmount = sql.NullInt64{Int64: c.shows, Valid: true}
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+func (s *Storage) findSlotBannerStatsSince(slotID, groupID string, since time.Time) []storage.SlotBannerStat {
+	since = since.Truncate(time.Hour)
+	var stats []storage.SlotBannerStat
+	for _, bannerID := range s.slotBanners[slotID] {
+		stat := storage.SlotBannerStat{SlotID: slotID, BannerID: bannerID}
+		for bucketStart, c := range s.buckets[statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}] {
+			if bucketStart.Before(since) {
+				continue
+			}
+			stat.ClickAmount = sql.NullInt64{Int64: stat.ClickAmount.Int64 + c.clicks, Valid: true}
+			stat.ShowAmount = sql.NullInt64{Int64: stat.ShowAmount.Int64 + c.shows, Valid: true}
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// InStatsTx executes fn holding the storage lock, so units of work are serialized.
+// Shows stored by fn are applied only if fn returns no error.
+func (s *Storage) InStatsTx(_ context.Context, fn func(tx storage.StatsTx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &statsTx{s: s}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return s.persistShowKeys(tx.shows)
+}
+
+// statsTx implements storage.StatsTx over locked in-memory storage.
+type statsTx struct {
+	s     *Storage
+	shows []statKey
+}
+
+func (t *statsTx) FindSlotBannerStats(_ context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	return t.s.findSlotBannerStats(slotID, groupID), nil
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotBannerStatsSince(_ context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	return t.s.findSlotBannerStatsSince(slotID, groupID, since), nil
+}
+
+func (t *statsTx) PersistShow(_ context.Context, slotID, groupID, bannerID string) error {
+	return t.addShows(slotID, groupID, []string{bannerID})
+}
+
+func (t *statsTx) PersistShows(_ context.Context, slotID, groupID string, bannerIDs []string) error {
+	return t.addShows(slotID, groupID, bannerIDs)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStats(_ context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	return t.s.findSlotsBannerStats(slotIDs, groupID), nil
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStatsSince(_ context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	return t.s.findSlotsBannerStatsSince(slotIDs, groupID, since), nil
+}
+
+func (t *statsTx) PersistSlotsShows(_ context.Context, groupID string, shows []storage.SlotBanner) error {
+	for _, show := range shows {
+		if err := t.addShows(show.SlotID, groupID, []string{show.BannerID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *statsTx) addShows(slotID, groupID string, bannerIDs []string) error {
+	for _, bannerID := range bannerIDs {
+		key := statKey{slotID: slotID, groupID: groupID, bannerID: bannerID}
+		if err := t.s.checkStatKey(key); err != nil {
+			return err
+		}
+		t.shows = append(t.shows, key)
+	}
+	return nil
+}
@@ -0,0 +1,445 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSlotBanner(ctx context.Context, t *testing.T, s *Storage) (slotID, groupID, bannerID string) {
+	t.Helper()
+	slotID, err := s.AddSlot(ctx, "slot")
+	require.NoError(t, err)
+	bannerID, err = s.AddBanner(ctx, "banner")
+	require.NoError(t, err)
+	groupID, err = s.AddGroup(ctx, "group")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, slotID, bannerID))
+	return slotID, groupID, bannerID
+}
+
+func TestEntities(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+
+	slotID, err := s.AddSlot(ctx, "slot")
+	require.NoError(t, err)
+	slot, err := s.GetSlotByID(ctx, slotID)
+	require.NoError(t, err)
+	require.Equal(t, storage.Slot{ID: slotID, Description: "slot"}, slot)
+
+	bannerID, err := s.AddBanner(ctx, "banner")
+	require.NoError(t, err)
+	banner, err := s.GetBannerByID(ctx, bannerID)
+	require.NoError(t, err)
+	require.Equal(t, storage.Banner{ID: bannerID, Description: "banner"}, banner)
+
+	groupID, err := s.AddGroup(ctx, "group")
+	require.NoError(t, err)
+	group, err := s.GetGroupByID(ctx, groupID)
+	require.NoError(t, err)
+	require.Equal(t, storage.SocialGroup{ID: groupID, Description: "group"}, group)
+
+	require.True(t, errors.Is(s.AddBannerToSlot(ctx, "unknown", bannerID), storage.ErrSlotNotFound))
+	require.True(t, errors.Is(s.AddBannerToSlot(ctx, slotID, "unknown"), storage.ErrBannerNotFound))
+	require.NoError(t, s.AddBannerToSlot(ctx, slotID, bannerID))
+	require.True(t, errors.Is(s.AddBannerToSlot(ctx, slotID, bannerID), storage.ErrSlotToBannerRelationExists))
+	require.NoError(t, s.DeleteBannerFromSlot(ctx, slotID, bannerID))
+	err = s.DeleteBannerFromSlot(ctx, slotID, bannerID)
+	require.True(t, errors.Is(err, storage.ErrSlotToBannerRelationNotFound))
+
+	require.NoError(t, s.DeleteSlot(ctx, slotID))
+	require.True(t, errors.Is(s.DeleteSlot(ctx, slotID), storage.ErrSlotNotFound))
+	_, err = s.GetSlotByID(ctx, slotID)
+	require.True(t, errors.Is(err, storage.ErrSlotNotFound))
+	require.NoError(t, s.DeleteBanner(ctx, bannerID))
+	require.True(t, errors.Is(s.DeleteBanner(ctx, bannerID), storage.ErrBannerNotFound))
+	require.NoError(t, s.DeleteGroup(ctx, groupID))
+	require.True(t, errors.Is(s.DeleteGroup(ctx, groupID), storage.ErrGroupNotFound))
+}
+
+func TestSlotSettings(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	slotID, _, _ := newTestSlotBanner(ctx, t, s)
+
+	settings, err := s.GetSlotSettings(ctx, slotID)
+	require.NoError(t, err)
+	require.True(t, settings.IsEmpty())
+
+	settings.Strategy.String, settings.Strategy.Valid = "thompson", true
+	require.NoError(t, s.SetSlotSettings(ctx, settings))
+	settingsList, err := s.GetSlotsSettings(ctx, []string{slotID, "unknown"})
+	require.NoError(t, err)
+	require.Equal(t, []storage.SlotSettings{settings}, settingsList)
+
+	err = s.SetSlotSettings(ctx, storage.SlotSettings{SlotID: "unknown"})
+	require.True(t, errors.Is(err, storage.ErrSlotNotFound))
+
+	require.NoError(t, s.DeleteSlot(ctx, slotID))
+	_, err = s.GetSlotSettings(ctx, slotID)
+	require.True(t, errors.Is(err, storage.ErrSlotNotFound))
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	now := time.Date(2021, 8, 12, 10, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	err := s.PersistClick(ctx, slotID, groupID, bannerID)
+	require.True(t, errors.Is(err, storage.ErrBannerNotShown))
+	err = s.PersistShow(ctx, slotID, "unknown", bannerID)
+	require.True(t, errors.Is(err, storage.ErrGroupNotFound))
+
+	require.NoError(t, s.PersistShow(ctx, slotID, groupID, bannerID))
+	require.NoError(t, s.PersistClick(ctx, slotID, groupID, bannerID))
+	now = now.Add(time.Hour)
+	require.NoError(t, s.PersistShows(ctx, slotID, groupID, []string{bannerID, bannerID}))
+
+	stats, err := s.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, int64(3), stats[0].GetShows())
+	require.Equal(t, int64(1), stats[0].GetClicks())
+
+	stats, err = s.FindSlotBannerStatsSince(ctx, slotID, groupID, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats[0].GetShows())
+	require.Equal(t, int64(0), stats[0].GetClicks())
+
+	slotsStats, err := s.FindSlotsBannerStats(ctx, []string{slotID, "unknown"}, groupID)
+	require.NoError(t, err)
+	require.Len(t, slotsStats, 1)
+	require.Equal(t, int64(3), slotsStats[slotID][0].GetShows())
+
+	// stats are removed with the banner
+	require.NoError(t, s.DeleteBanner(ctx, bannerID))
+	stats, err = s.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+	err = s.PersistClick(ctx, slotID, groupID, bannerID)
+	require.True(t, errors.Is(err, storage.ErrBannerNotShown))
+}
+
+func TestClickDeduplication(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	require.NoError(t, s.PersistShow(ctx, slotID, groupID, bannerID))
+
+	require.NoError(t, s.PersistImpressionClick(ctx, "impression", slotID, groupID, bannerID))
+	err := s.PersistImpressionClick(ctx, "impression", slotID, groupID, bannerID)
+	require.True(t, errors.Is(err, storage.ErrImpressionAlreadyClicked))
+
+	require.NoError(t, s.PersistClickOnce(ctx, "key", slotID, groupID, bannerID, time.Minute))
+	err = s.PersistClickOnce(ctx, "key", slotID, groupID, bannerID, time.Minute)
+	require.True(t, errors.Is(err, storage.ErrDuplicateClick))
+	now = now.Add(time.Minute * 2)
+	require.NoError(t, s.PersistClickOnce(ctx, "key", slotID, groupID, bannerID, time.Minute))
+
+	deleted, err := s.DeleteExpiredClickKeys(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	// impression was clicked before the keys were repeated
+	deleted, err = s.DeleteExpiredImpressionClicks(ctx, now.Add(-time.Minute*3))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), deleted)
+	deleted, err = s.DeleteExpiredImpressionClicks(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	stats, err := s.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats[0].GetClicks())
+}
+
+func TestStatsTx(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	// shows of failed unit of work are not stored
+	errTest := errors.New("test error")
+	err := s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		if err := tx.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+			return err
+		}
+		return errTest
+	})
+	require.True(t, errors.Is(err, errTest))
+	stats, err := s.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), stats[0].GetShows())
+
+	const workers = 50
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	seenShows := make(map[int64]struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shows int64
+			err := s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+				stats, err := tx.FindSlotBannerStats(ctx, slotID, groupID)
+				if err != nil {
+					return err
+				}
+				shows = stats[0].GetShows()
+				return tx.PersistShow(ctx, slotID, groupID, bannerID)
+			})
+			require.NoError(t, err)
+			mu.Lock()
+			seenShows[shows] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seenShows, workers)
+}
+
+func TestSlotsStatsTx(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	firstSlotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	secondSlotID, err := s.AddSlot(ctx, "slot")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, secondSlotID, bannerID))
+
+	slotIDs := []string{secondSlotID, firstSlotID}
+	require.NoError(t, s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		stats, err := tx.FindSlotsBannerStats(ctx, slotIDs, groupID)
+		if err != nil {
+			return err
+		}
+		require.Len(t, stats, 2)
+		require.Equal(t, int64(0), stats[firstSlotID][0].GetShows())
+		return tx.PersistSlotsShows(ctx, groupID, []storage.SlotBanner{
+			{SlotID: firstSlotID, BannerID: bannerID},
+			{SlotID: secondSlotID, BannerID: bannerID},
+		})
+	}))
+	require.NoError(t, s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		stats, err := tx.FindSlotsBannerStatsSince(ctx, slotIDs, groupID, time.Now().Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		require.Equal(t, int64(1), stats[firstSlotID][0].GetShows())
+		require.Equal(t, int64(1), stats[secondSlotID][0].GetShows())
+		return nil
+	}))
+}
+
+func TestPersistStats(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	bucketStart := time.Now().Truncate(time.Hour)
+
+	err := s.PersistStats(ctx, []storage.StatsDelta{
+		// clicks of not shown banner are skipped
+		{SlotID: slotID, GroupID: groupID, BannerID: bannerID, BucketStart: bucketStart, Clicks: 5},
+		{SlotID: slotID, GroupID: groupID, BannerID: bannerID, BucketStart: bucketStart, Shows: 3},
+		{SlotID: slotID, GroupID: groupID, BannerID: bannerID, BucketStart: bucketStart, Clicks: 2},
+		// stats of unknown entities are skipped
+		{SlotID: slotID, GroupID: "unknown", BannerID: bannerID, BucketStart: bucketStart, Shows: 1},
+	})
+	require.NoError(t, err)
+
+	stats, err := s.FindSlotBannerStatsSince(ctx, slotID, groupID, bucketStart)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, int64(3), stats[0].GetShows())
+	require.Equal(t, int64(2), stats[0].GetClicks())
+}
+
+func TestFindStatsBuckets(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	now := time.Date(2021, 8, 12, 10, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	otherBannerID, err := s.AddBanner(ctx, "other banner")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, slotID, otherBannerID))
+
+	require.NoError(t, s.PersistShow(ctx, slotID, groupID, bannerID))
+	require.NoError(t, s.PersistClick(ctx, slotID, groupID, bannerID))
+	require.NoError(t, s.PersistShow(ctx, slotID, groupID, otherBannerID))
+	now = now.Add(time.Hour)
+	require.NoError(t, s.PersistShows(ctx, slotID, groupID, []string{bannerID, bannerID}))
+	now = now.Add(time.Hour)
+	require.NoError(t, s.PersistShow(ctx, slotID, groupID, bannerID))
+
+	firstHour := time.Date(2021, 8, 12, 10, 0, 0, 0, time.UTC)
+	buckets, err := s.FindStatsBuckets(ctx, storage.StatsBucketsFilter{
+		SlotID:   slotID,
+		BannerID: bannerID,
+		From:     firstHour.Add(time.Minute * 10),
+		To:       firstHour.Add(time.Hour * 2),
+	})
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	require.True(t, buckets[0].BucketStart.Equal(firstHour))
+	require.Equal(t, storage.StatsBucket{
+		SlotID:      slotID,
+		GroupID:     groupID,
+		BannerID:    bannerID,
+		BucketStart: buckets[0].BucketStart,
+		Clicks:      1,
+		Shows:       1,
+	}, buckets[0])
+	require.True(t, buckets[1].BucketStart.Equal(firstHour.Add(time.Hour)))
+	require.Equal(t, int64(2), buckets[1].Shows)
+
+	buckets, err = s.FindStatsBuckets(ctx, storage.StatsBucketsFilter{
+		GroupID: groupID,
+		From:    firstHour,
+		To:      firstHour.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+
+	// zero to means no upper bound
+	buckets, err = s.FindStatsBuckets(ctx, storage.StatsBucketsFilter{
+		SlotID:   slotID,
+		BannerID: bannerID,
+		From:     firstHour.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	require.True(t, buckets[1].BucketStart.Equal(firstHour.Add(time.Hour*2)))
+}
+
+func TestListEntities(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	otherSlotID, err := s.AddSlot(ctx, "other slot")
+	require.NoError(t, err)
+	otherBannerID, err := s.AddBanner(ctx, "other banner")
+	require.NoError(t, err)
+
+	slots, err := s.ListSlots(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	require.Less(t, slots[0].ID, slots[1].ID)
+	require.ElementsMatch(t, []string{slotID, otherSlotID}, []string{slots[0].ID, slots[1].ID})
+	slots, err = s.ListSlots(ctx, "", 1)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	nextSlots, err := s.ListSlots(ctx, slots[0].ID, 1)
+	require.NoError(t, err)
+	require.Len(t, nextSlots, 1)
+	require.Greater(t, nextSlots[0].ID, slots[0].ID)
+
+	banners, err := s.ListBanners(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, banners, 2)
+	groups, err := s.ListGroups(ctx, groupID, 10)
+	require.NoError(t, err)
+	require.Empty(t, groups)
+
+	slotBanners, err := s.FindSlotBanners(ctx, slotID)
+	require.NoError(t, err)
+	require.Equal(t, []storage.Banner{{ID: bannerID, Description: "banner"}}, slotBanners)
+	require.NotEqual(t, otherBannerID, slotBanners[0].ID)
+}
+
+func TestUpdateEntities(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	require.NoError(t, s.UpdateSlot(ctx, storage.Slot{ID: slotID, Description: "new slot"}))
+	slot, err := s.GetSlotByID(ctx, slotID)
+	require.NoError(t, err)
+	require.Equal(t, "new slot", slot.Description)
+	require.NoError(t, s.UpdateBanner(ctx, storage.Banner{ID: bannerID, Description: "new banner"}))
+	banner, err := s.GetBannerByID(ctx, bannerID)
+	require.NoError(t, err)
+	require.Equal(t, "new banner", banner.Description)
+	require.NoError(t, s.UpdateGroup(ctx, storage.SocialGroup{ID: groupID, Description: "new group"}))
+	group, err := s.GetGroupByID(ctx, groupID)
+	require.NoError(t, err)
+	require.Equal(t, "new group", group.Description)
+
+	err = s.UpdateSlot(ctx, storage.Slot{ID: "unknown", Description: "slot"})
+	require.True(t, errors.Is(err, storage.ErrSlotNotFound))
+	err = s.UpdateBanner(ctx, storage.Banner{ID: "unknown", Description: "banner"})
+	require.True(t, errors.Is(err, storage.ErrBannerNotFound))
+	err = s.UpdateGroup(ctx, storage.SocialGroup{ID: "unknown", Description: "group"})
+	require.True(t, errors.Is(err, storage.ErrGroupNotFound))
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	s := NewStorage()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	require.NoError(t, s.PersistShows(ctx, slotID, groupID, []string{bannerID, bannerID}))
+	require.NoError(t, s.PersistClick(ctx, slotID, groupID, bannerID))
+	// events of failed unit of work are not stored
+	errTest := errors.New("test error")
+	err := s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+		if err := tx.PersistShow(ctx, slotID, groupID, bannerID); err != nil {
+			return err
+		}
+		return errTest
+	})
+	require.True(t, errors.Is(err, errTest))
+	// events of the late flushed increment are created at its bucket start
+	lateBucketStart := now.Add(-time.Hour * 2).Truncate(time.Hour)
+	require.NoError(t, s.PersistStats(ctx, []storage.StatsDelta{
+		{SlotID: slotID, GroupID: groupID, BannerID: bannerID, BucketStart: lateBucketStart, Clicks: 1, Shows: 1},
+	}))
+
+	eventTypes := func(events []storage.OutboxEvent) []string {
+		types := make([]string, 0, len(events))
+		for _, event := range events {
+			require.Equal(t, slotID, event.SlotID)
+			require.Equal(t, groupID, event.GroupID)
+			require.Equal(t, bannerID, event.BannerID)
+			types = append(types, event.Type)
+		}
+		return types
+	}
+	first, err := s.ClaimOutboxEvents(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []string{storage.EventTypeShow, storage.EventTypeShow}, eventTypes(first))
+	second, err := s.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []string{storage.EventTypeClick, storage.EventTypeClick, storage.EventTypeShow}, eventTypes(second))
+	for _, event := range append(first, second[0]) {
+		require.Equal(t, now.Unix(), event.CreatedAt.Unix())
+	}
+	for _, event := range second[1:] {
+		require.Equal(t, lateBucketStart.Unix(), event.CreatedAt.Unix())
+	}
+	// claimed events are locked until the lease end
+	claimed, err := s.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	require.NoError(t, s.MarkOutboxEventsSent(ctx, []string{first[0].ID, first[1].ID}))
+	now = now.Add(time.Minute * 2)
+	claimed, err = s.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, second, claimed)
+
+	deleted, err := s.DeleteSentOutboxEvents(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+}
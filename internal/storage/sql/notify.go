@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// catalogChangesChannel is notified by the db triggers when slot banners are changed or groups are deleted.
+const catalogChangesChannel = "rotation_catalog_changes"
+
+const catalogListenRetryInterval = time.Second * 5
+
+// ListenCatalogChanges calls onChange for every catalog change made by any service instance until context is done.
+// Listening uses separate db connection which is reconnected on failures,
+// empty change is passed after every (re)connection, because notifications could be missed.
+func (s *Storage) ListenCatalogChanges(ctx context.Context, onChange func(change storage.CatalogChange)) {
+	for {
+		err := s.listenCatalogChanges(ctx, onChange)
+		if ctx.Err() != nil {
+			return
+		}
+		zap.L().Error("catalog changes listening failed, will reconnect", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(catalogListenRetryInterval):
+		}
+	}
+}
+
+func (s *Storage) listenCatalogChanges(ctx context.Context, onChange func(change storage.CatalogChange)) error {
+	conn, err := pgx.Connect(ctx, s.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to db: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			zap.L().Error("error during catalog changes listener connection closing", zap.Error(err))
+		}
+	}()
+	if _, err := conn.Exec(ctx, "LISTEN "+catalogChangesChannel); err != nil {
+		return fmt.Errorf("failed to listen %s: %w", catalogChangesChannel, err)
+	}
+	onChange(storage.CatalogChange{})
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		var change storage.CatalogChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			zap.L().Error("invalid catalog change notification",
+				zap.String("payload", notification.Payload), zap.Error(err))
+			change = storage.CatalogChange{}
+		}
+		onChange(change)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Raschudesny/otus_project/v1/internal/config"
 	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/jackc/pgconn"
 	"github.com/jmoiron/sqlx"
 	sqldblogger "github.com/simukti/sqldb-logger"
 	"github.com/simukti/sqldb-logger/logadapter/zapadapter"
@@ -22,6 +25,8 @@ type Storage struct {
 	maxOpenConnections    int
 	maxIdleConnections    int
 	maxConnectionLifetime time.Duration
+	isolation             string
+	maxTxRetries          int
 }
 
 func NewStorage(driverName string, cnf config.DBConfig) *Storage {
@@ -31,10 +36,20 @@ func NewStorage(driverName string, cnf config.DBConfig) *Storage {
 		maxOpenConnections:    cnf.MaxOpenConnections,
 		maxIdleConnections:    cnf.MaxIdleConnections,
 		maxConnectionLifetime: cnf.MaxConnectionLifetime,
+		isolation:             cnf.Isolation,
+		maxTxRetries:          cnf.MaxTxRetries,
 	}
 }
 
 func (s *Storage) Connect(ctx context.Context) (err error) {
+	switch s.isolation {
+	case "":
+		s.isolation = storage.IsolationLock
+	case storage.IsolationLock, storage.IsolationOptimistic:
+	default:
+		return fmt.Errorf("%w: %q", storage.ErrUnknownIsolation, s.isolation)
+	}
+
 	db, err := sql.Open(s.driverName, s.dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open db connection: %w", err)
@@ -104,6 +119,23 @@ func (s *Storage) GetSlotByID(ctx context.Context, id string) (storage.Slot, err
 	}
 }
 
+// UpdateSlot updates description of the slot.
+func (s *Storage) UpdateSlot(ctx context.Context, slot storage.Slot) error {
+	query := "UPDATE slots SET slot_description = :description WHERE slot_id = :id"
+	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": slot.ID, "description": slot.Description})
+	if err != nil {
+		return fmt.Errorf("sql update slot query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by update checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrSlotNotFound
+	}
+	return nil
+}
+
 func (s *Storage) DeleteSlot(ctx context.Context, id string) error {
 	query := "DELETE FROM slots WHERE slot_id = :id"
 	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": id})
@@ -120,6 +152,74 @@ func (s *Storage) DeleteSlot(ctx context.Context, id string) error {
 	return nil
 }
 
+func (s *Storage) GetSlotSettings(ctx context.Context, slotID string) (storage.SlotSettings, error) {
+	query := `SELECT s.slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds
+			  FROM slots s
+			  LEFT JOIN slot_settings ss ON s.slot_id = ss.slot_id
+			  WHERE s.slot_id = $1;`
+	row := s.db.QueryRowxContext(ctx, query, slotID)
+	if err := row.Err(); err != nil {
+		return storage.SlotSettings{}, fmt.Errorf("sql execution error: %w", err)
+	}
+
+	settings := new(storage.SlotSettings)
+	err := row.StructScan(settings)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.SlotSettings{}, storage.ErrSlotNotFound
+	case err != nil:
+		return storage.SlotSettings{}, fmt.Errorf("sql GetSlotSettings result scan error: %w", err)
+	default:
+		return *settings, nil
+	}
+}
+
+// GetSlotsSettings returns settings of all existing slots from the provided list.
+func (s *Storage) GetSlotsSettings(ctx context.Context, slotIDs []string) ([]storage.SlotSettings, error) {
+	query, args, err := sqlx.In(`SELECT s.slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds
+			  FROM slots s
+			  LEFT JOIN slot_settings ss ON s.slot_id = ss.slot_id
+			  WHERE s.slot_id IN (?);`, slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	var settings []storage.SlotSettings
+	if err := s.db.SelectContext(ctx, &settings, s.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("sql execution error: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *Storage) SetSlotSettings(ctx context.Context, settings storage.SlotSettings) error {
+	query := `INSERT INTO slot_settings (slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds)
+			  SELECT slot_id, :strategy, :exploration_constant, :epsilon, :epsilon_decay,
+			  	:thompson_alpha, :thompson_beta, :window_seconds
+			  FROM slots WHERE slot_id = :slot_id
+			  ON CONFLICT (slot_id) DO UPDATE
+			  SET strategy = EXCLUDED.strategy,
+			      exploration_constant = EXCLUDED.exploration_constant,
+			      epsilon = EXCLUDED.epsilon,
+			      epsilon_decay = EXCLUDED.epsilon_decay,
+			      thompson_alpha = EXCLUDED.thompson_alpha,
+			      thompson_beta = EXCLUDED.thompson_beta,
+			      window_seconds = EXCLUDED.window_seconds`
+	res, err := s.db.NamedExecContext(ctx, query, settings)
+	if err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during sql rows affected checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrSlotNotFound
+	}
+	return nil
+}
+
 func (s *Storage) AddBanner(ctx context.Context, description string) (string, error) {
 	query := "INSERT INTO banners (banner_description) VALUES (:description) RETURNING banner_id"
 	rows, err := s.db.NamedQueryContext(ctx, query, map[string]interface{}{"description": description})
@@ -155,7 +255,7 @@ func (s *Storage) GetBannerByID(ctx context.Context, id string) (storage.Banner,
 	err := row.StructScan(banner)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
-		return storage.Banner{}, storage.ErrSlotNotFound
+		return storage.Banner{}, storage.ErrBannerNotFound
 	case err != nil:
 		return storage.Banner{}, fmt.Errorf("sql GetBannerById result scan error: %w", err)
 	default:
@@ -163,6 +263,23 @@ func (s *Storage) GetBannerByID(ctx context.Context, id string) (storage.Banner,
 	}
 }
 
+// UpdateBanner updates description of the banner.
+func (s *Storage) UpdateBanner(ctx context.Context, banner storage.Banner) error {
+	query := "UPDATE banners SET banner_description = :description WHERE banner_id = :id"
+	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": banner.ID, "description": banner.Description})
+	if err != nil {
+		return fmt.Errorf("sql update banner query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by update checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrBannerNotFound
+	}
+	return nil
+}
+
 func (s *Storage) DeleteBanner(ctx context.Context, id string) error {
 	query := "DELETE FROM banners WHERE banner_id = :id"
 	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": id})
@@ -180,14 +297,22 @@ func (s *Storage) DeleteBanner(ctx context.Context, id string) error {
 }
 
 func (s *Storage) AddBannerToSlot(ctx context.Context, slotID, bannerID string) error {
-	query := "INSERT INTO slot_banners (slot_id, banner_id) VALUES (:slotId, :bannerId)"
-	_, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{
+	query := `INSERT INTO slot_banners (slot_id, banner_id) VALUES (:slotId, :bannerId)
+			  ON CONFLICT ON CONSTRAINT slot_banner_pkey DO NOTHING`
+	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{
 		"slotId":   slotID,
 		"bannerId": bannerID,
 	})
 	if err != nil {
 		return fmt.Errorf("error during sql execution: %w", err)
 	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during sql rows affected checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrSlotToBannerRelationExists
+	}
 	return nil
 }
 
@@ -252,6 +377,23 @@ func (s *Storage) GetGroupByID(ctx context.Context, groupID string) (storage.Soc
 	}
 }
 
+// UpdateGroup updates description of the social group.
+func (s *Storage) UpdateGroup(ctx context.Context, group storage.SocialGroup) error {
+	query := "UPDATE social_groups SET group_description = :description WHERE group_id = :id"
+	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": group.ID, "description": group.Description})
+	if err != nil {
+		return fmt.Errorf("sql update social group query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by update checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrGroupNotFound
+	}
+	return nil
+}
+
 func (s *Storage) DeleteGroup(ctx context.Context, id string) error {
 	query := "DELETE FROM social_groups WHERE group_id = :id;"
 	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{"id": id})
@@ -269,14 +411,220 @@ func (s *Storage) DeleteGroup(ctx context.Context, id string) error {
 }
 
 func (s *Storage) PersistClick(ctx context.Context, slotID, groupID, bannerID string) error {
-	query := `UPDATE banner_stats
-			  SET clicks_amount = clicks_amount + 1
-			  WHERE slot_id = :slotId AND group_id = :groupId AND banner_id = :bannerId`
-	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{
-		"bannerId": bannerID,
-		"slotId":   slotID,
-		"groupId":  groupID,
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return persistClick(ctx, tx, slotID, groupID, bannerID)
 	})
+}
+
+// PersistImpressionClick stores click of the impression, only the first click of each impression is counted.
+//
+//nolint:lll
+func (s *Storage) PersistImpressionClick(ctx context.Context, impressionID, slotID, groupID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO impression_clicks (impression_id, slot_id, group_id, banner_id)
+				  VALUES (:impressionId, :slotId, :groupId, :bannerId)
+				  ON CONFLICT (impression_id) DO NOTHING`
+		res, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
+			"impressionId": impressionID,
+			"slotId":       slotID,
+			"groupId":      groupID,
+			"bannerId":     bannerID,
+		})
+		if err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		}
+		if affected == 0 {
+			return storage.ErrImpressionAlreadyClicked
+		}
+		return persistClick(ctx, tx, slotID, groupID, bannerID)
+	})
+}
+
+// DeleteExpiredImpressionClicks removes impression clicks counted before the provided time.
+func (s *Storage) DeleteExpiredImpressionClicks(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM impression_clicks WHERE clicked_at < $1"
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("sql delete expired impression clicks query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+// PersistClickOnce stores click with the idempotency key,
+// repeated clicks with the same key are not counted during the dedup window.
+//
+//nolint:lll
+func (s *Storage) PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO click_idempotency_keys (idempotency_key)
+				  VALUES (:idempotencyKey)
+				  ON CONFLICT (idempotency_key) DO UPDATE
+				  SET created_at = now()
+				  WHERE click_idempotency_keys.created_at < now() - make_interval(secs => :windowSeconds)`
+		res, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
+			"idempotencyKey": idempotencyKey,
+			"windowSeconds":  window.Seconds(),
+		})
+		if err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		}
+		if affected == 0 {
+			return storage.ErrDuplicateClick
+		}
+		return persistClick(ctx, tx, slotID, groupID, bannerID)
+	})
+}
+
+// DeleteExpiredClickKeys removes idempotency keys created before the provided time.
+func (s *Storage) DeleteExpiredClickKeys(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM click_idempotency_keys WHERE created_at < $1"
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("sql delete expired click keys query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+func persistClick(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string) error {
+	return persistStat(ctx, tx, slotID, groupID, bannerID, 1, 0)
+}
+
+func (s *Storage) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return persistShow(ctx, tx, slotID, groupID, bannerID)
+	})
+}
+
+// PersistShows stores shows of several banners in one transaction.
+func (s *Storage) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return persistShows(ctx, tx, groupID, slotBanners(slotID, bannerIDs))
+	})
+}
+
+func slotBanners(slotID string, bannerIDs []string) []storage.SlotBanner {
+	banners := make([]storage.SlotBanner, 0, len(bannerIDs))
+	for _, bannerID := range bannerIDs {
+		banners = append(banners, storage.SlotBanner{SlotID: slotID, BannerID: bannerID})
+	}
+	return banners
+}
+
+func persistShow(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string) error {
+	return persistStat(ctx, tx, slotID, groupID, bannerID, 0, 1)
+}
+
+// PersistSlotsShows stores shows of banners in different slots for a group in one transaction.
+func (s *Storage) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return persistShows(ctx, tx, groupID, shows)
+	})
+}
+
+// persistShows adds shows of banners in the slots for a group with one upsert of banner stats,
+// one upsert of the current hour buckets and one insert of outbox events whatever amount of shows is passed.
+func persistShows(ctx context.Context, tx *sqlx.Tx, groupID string, shows []storage.SlotBanner) error {
+	if len(shows) == 0 {
+		return nil
+	}
+	// one statement can't update the same row twice, so shows of the same banner are summed up,
+	// and rows are sorted to be locked in the same order by concurrent transactions
+	amounts := make(map[storage.SlotBanner]int, len(shows))
+	for _, show := range shows {
+		amounts[show]++
+	}
+	banners := make([]storage.SlotBanner, 0, len(amounts))
+	for banner := range amounts {
+		banners = append(banners, banner)
+	}
+	sort.Slice(banners, func(i, j int) bool {
+		if banners[i].SlotID != banners[j].SlotID {
+			return banners[i].SlotID < banners[j].SlotID
+		}
+		return banners[i].BannerID < banners[j].BannerID
+	})
+	slotIDs := make([]string, 0, len(banners))
+	bannerIDs := make([]string, 0, len(banners))
+	showAmounts := make([]int64, 0, len(banners))
+	for _, banner := range banners {
+		slotIDs = append(slotIDs, banner.SlotID)
+		bannerIDs = append(bannerIDs, banner.BannerID)
+		showAmounts = append(showAmounts, int64(amounts[banner]))
+	}
+
+	const showsTable = `unnest(CAST($2 AS uuid[]), CAST($3 AS uuid[]), CAST($4 AS int[])) AS s(slot_id, banner_id, shows)`
+	query := `INSERT INTO banner_stats (slot_id, banner_id, group_id, clicks_amount, shows_amount)
+			  SELECT s.slot_id, s.banner_id, CAST($1 AS uuid), 0, s.shows FROM ` + showsTable + `
+			  ON CONFLICT ON CONSTRAINT banner_stats_pkey DO UPDATE
+			  SET shows_amount = banner_stats.shows_amount + EXCLUDED.shows_amount`
+	if _, err := tx.ExecContext(ctx, query, groupID, slotIDs, bannerIDs, showAmounts); err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	query = `INSERT INTO banner_stats_buckets (slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount)
+			 SELECT s.slot_id, CAST($1 AS uuid), s.banner_id, date_trunc('hour', now()), 0, s.shows FROM ` + showsTable + `
+			 ON CONFLICT ON CONSTRAINT banner_stats_buckets_pkey DO UPDATE
+			 SET shows_amount = banner_stats_buckets.shows_amount + EXCLUDED.shows_amount`
+	if _, err := tx.ExecContext(ctx, query, groupID, slotIDs, bannerIDs, showAmounts); err != nil {
+		return fmt.Errorf("error during stats bucket sql execution: %w", err)
+	}
+	query = `INSERT INTO outbox (event_type, slot_id, group_id, banner_id, created_at)
+			 SELECT $5, s.slot_id, CAST($1 AS uuid), s.banner_id, now() FROM ` + showsTable + `, generate_series(1, s.shows)`
+	if _, err := tx.ExecContext(ctx, query, groupID, slotIDs, bannerIDs, showAmounts, storage.EventTypeShow); err != nil {
+		return fmt.Errorf("error during outbox events sql execution: %w", err)
+	}
+	return nil
+}
+
+// PersistStats applies stats increments in one transaction. Increments of deleted slots, groups or banners
+// and clicks of banners which weren't shown are skipped, so one bad increment doesn't fail the whole batch.
+func (s *Storage) PersistStats(ctx context.Context, deltas []storage.StatsDelta) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		for _, delta := range deltas {
+			if err := persistStatsDelta(ctx, tx, delta); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func persistStatsDelta(ctx context.Context, tx *sqlx.Tx, delta storage.StatsDelta) error {
+	query := `INSERT INTO banner_stats (slot_id, banner_id, group_id, clicks_amount, shows_amount)
+			  SELECT s.slot_id, b.banner_id, g.group_id, CAST(:clicks AS int), CAST(:shows AS int)
+			  FROM slots s, banners b, social_groups g
+			  WHERE s.slot_id = :slotId AND b.banner_id = :bannerId AND g.group_id = :groupId
+			  	AND (CAST(:shows AS int) > 0 OR EXISTS (
+			  		SELECT 1 FROM banner_stats bs
+			  		WHERE bs.slot_id = s.slot_id AND bs.group_id = g.group_id AND bs.banner_id = b.banner_id
+			  	))
+			  ON CONFLICT ON CONSTRAINT banner_stats_pkey DO UPDATE
+			  SET clicks_amount = banner_stats.clicks_amount + EXCLUDED.clicks_amount,
+			      shows_amount = banner_stats.shows_amount + EXCLUDED.shows_amount`
+	args := map[string]interface{}{
+		"slotId":      delta.SlotID,
+		"groupId":     delta.GroupID,
+		"bannerId":    delta.BannerID,
+		"clicks":      delta.Clicks,
+		"shows":       delta.Shows,
+		"bucketStart": delta.BucketStart,
+	}
+	res, err := tx.NamedExecContext(ctx, query, args)
 	if err != nil {
 		return fmt.Errorf("error during sql execution: %w", err)
 	}
@@ -284,21 +632,44 @@ func (s *Storage) PersistClick(ctx context.Context, slotID, groupID, bannerID st
 	if err != nil {
 		return fmt.Errorf("error during sql rows affected checking: %w", err)
 	}
-
 	if affected == 0 {
-		return storage.ErrBannerNotShown
+		return nil
 	}
-	return nil
+
+	query = `INSERT INTO banner_stats_buckets (slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount)
+			 VALUES (:slotId, :groupId, :bannerId, date_trunc('hour', CAST(:bucketStart AS timestamptz)), :clicks, :shows)
+			 ON CONFLICT ON CONSTRAINT banner_stats_buckets_pkey DO UPDATE
+			 SET clicks_amount = banner_stats_buckets.clicks_amount + EXCLUDED.clicks_amount,
+			     shows_amount = banner_stats_buckets.shows_amount + EXCLUDED.shows_amount`
+	if _, err := tx.NamedExecContext(ctx, query, args); err != nil {
+		return fmt.Errorf("error during stats bucket sql execution: %w", err)
+	}
+	// events of the late flushed increment get the time of its bucket like the bucket row
+	createdAt := sql.NullTime{Time: delta.BucketStart, Valid: true}
+	return addOutboxEvents(ctx, tx, delta.SlotID, delta.GroupID, delta.BannerID, delta.Clicks, delta.Shows, createdAt)
 }
 
-func (s *Storage) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
-	query := `UPDATE banner_stats 
-			  SET shows_amount = shows_amount + 1 
-			  WHERE slot_id = :slotId AND group_id = :groupId AND banner_id = :bannerId`
-	res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{
-		"bannerId": bannerID,
+// persistStat adds clicks and shows to banner stats with a single upsert, so concurrent first shows
+// of the same banner don't fail on the primary key. Stats row is created only by shows,
+// clicks of the banner which wasn't shown yet are not counted and ErrBannerNotShown is returned.
+//
+//nolint:lll
+func persistStat(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string, clicks, shows int) error {
+	query := `INSERT INTO banner_stats (slot_id, banner_id, group_id, clicks_amount, shows_amount)
+			  SELECT CAST(:slotId AS uuid), CAST(:bannerId AS uuid), CAST(:groupId AS uuid), CAST(:clicks AS int), CAST(:shows AS int)
+			  WHERE CAST(:shows AS int) > 0 OR EXISTS (
+			  	SELECT 1 FROM banner_stats
+			  	WHERE slot_id = CAST(:slotId AS uuid) AND group_id = CAST(:groupId AS uuid) AND banner_id = CAST(:bannerId AS uuid)
+			  )
+			  ON CONFLICT ON CONSTRAINT banner_stats_pkey DO UPDATE
+			  SET clicks_amount = banner_stats.clicks_amount + EXCLUDED.clicks_amount,
+			      shows_amount = banner_stats.shows_amount + EXCLUDED.shows_amount`
+	res, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
 		"slotId":   slotID,
 		"groupId":  groupID,
+		"bannerId": bannerID,
+		"clicks":   clicks,
+		"shows":    shows,
 	})
 	if err != nil {
 		return fmt.Errorf("error during sql execution: %w", err)
@@ -307,31 +678,253 @@ func (s *Storage) PersistShow(ctx context.Context, slotID, groupID, bannerID str
 	if err != nil {
 		return fmt.Errorf("error during sql rows affected checking: %w", err)
 	}
-
-	// if no rows affected by query, than this method should to init stats for current banner
 	if affected == 0 {
-		query := `INSERT INTO banner_stats (slot_id, banner_id, group_id, clicks_amount, shows_amount)
- 				  VALUES (:slotId, :bannerId, :groupId, 0, 1)`
-		res, err := s.db.NamedExecContext(ctx, query, map[string]interface{}{
-			"slotId":   slotID,
-			"bannerId": bannerID,
-			"groupId":  groupID,
-		})
-		if err != nil {
-			return fmt.Errorf("error during sql execution: %w", err)
+		return storage.ErrBannerNotShown
+	}
+	if err := persistBucketStat(ctx, tx, slotID, groupID, bannerID, clicks, shows); err != nil {
+		return err
+	}
+	return addOutboxEvents(ctx, tx, slotID, groupID, bannerID, int64(clicks), int64(shows), sql.NullTime{})
+}
+
+// persistBucketStat adds clicks and shows to the current hour bucket of banner stats.
+func persistBucketStat(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string, clicks, shows int) error {
+	query := `INSERT INTO banner_stats_buckets (slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount)
+			  VALUES (:slotId, :groupId, :bannerId, date_trunc('hour', now()), :clicks, :shows)
+			  ON CONFLICT ON CONSTRAINT banner_stats_buckets_pkey DO UPDATE
+			  SET clicks_amount = banner_stats_buckets.clicks_amount + EXCLUDED.clicks_amount,
+			      shows_amount = banner_stats_buckets.shows_amount + EXCLUDED.shows_amount`
+	if _, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
+		"slotId":   slotID,
+		"groupId":  groupID,
+		"bannerId": bannerID,
+		"clicks":   clicks,
+		"shows":    shows,
+	}); err != nil {
+		return fmt.Errorf("error during stats bucket sql execution: %w", err)
+	}
+	return nil
+}
+
+// addOutboxEvents stores click and show events of the stats increment to the outbox, one event per click or show.
+// Events are created at the provided time or at the current time if it's not set.
+//
+//nolint:lll
+func addOutboxEvents(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string, clicks, shows int64, createdAt sql.NullTime) error {
+	query := `INSERT INTO outbox (event_type, slot_id, group_id, banner_id, created_at)
+			  SELECT $1, CAST($2 AS uuid), CAST($3 AS uuid), CAST($4 AS uuid), COALESCE(CAST($6 AS timestamptz), now())
+			  FROM generate_series(1, CAST($5 AS bigint))`
+	for _, events := range []struct {
+		eventType string
+		amount    int64
+	}{{storage.EventTypeClick, clicks}, {storage.EventTypeShow, shows}} {
+		if events.amount <= 0 {
+			continue
 		}
-		rowsAffected, err := res.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		if _, err := tx.ExecContext(ctx, query, events.eventType, slotID, groupID, bannerID, events.amount, createdAt); err != nil {
+			return fmt.Errorf("error during outbox events sql execution: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClaimOutboxEvents returns up to limit not sent events in the order they were stored
+// and locks them for the lease time, so the same events are not published by other service instances.
+// Events which are not marked as sent until the lease end can be claimed again.
+//
+//nolint:lll
+func (s *Storage) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxEvent, error) {
+	query := `WITH claimed AS (
+			  	UPDATE outbox SET locked_until = now() + make_interval(secs => $2)
+			  	WHERE event_id IN (
+			  		SELECT event_id FROM outbox
+			  		WHERE sent_at IS NULL AND (locked_until IS NULL OR locked_until < now())
+			  		ORDER BY seq LIMIT $1
+			  		FOR UPDATE SKIP LOCKED
+			  	)
+			  	RETURNING seq, event_id, event_type, slot_id, group_id, banner_id, created_at
+			  )
+			  SELECT event_id, event_type, slot_id, group_id, banner_id, created_at FROM claimed ORDER BY seq`
+	var events []storage.OutboxEvent
+	if err := s.db.SelectContext(ctx, &events, query, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("sql claim outbox events query error: %w", err)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventsSent marks published events as sent.
+func (s *Storage) MarkOutboxEventsSent(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("UPDATE outbox SET sent_at = now() WHERE event_id IN (?)", ids)
+	if err != nil {
+		return fmt.Errorf("error during sql query building: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, s.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("sql mark outbox events sent query error: %w", err)
+	}
+	return nil
+}
+
+// DeleteSentOutboxEvents removes events sent before the provided time.
+func (s *Storage) DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM outbox WHERE sent_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("sql delete sent outbox events query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+// inTx executes fn in a transaction, transaction is committed only if fn returns no error.
+func (s *Storage) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	return s.inTxWithOptions(ctx, nil, fn)
+}
+
+//nolint:lll
+func (s *Storage) inTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			zap.L().Error("error during transaction rollback", zap.Error(rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// InStatsTx executes fn as a unit of work in which stats reading and shows storing are consistent.
+// With lock isolation the slot row is locked before its stats are read,
+// with optimistic isolation fn is executed in serializable transaction and retried on serialization failure,
+// so fn may be called several times.
+func (s *Storage) InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error {
+	if s.isolation != storage.IsolationOptimistic {
+		return s.inTx(ctx, func(tx *sqlx.Tx) error {
+			return fn(&statsTx{tx: tx, lockSlot: true})
+		})
+	}
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	for attempt := 0; ; attempt++ {
+		err := s.inTxWithOptions(ctx, opts, func(tx *sqlx.Tx) error {
+			return fn(&statsTx{tx: tx})
+		})
+		if !isSerializationFailure(err) {
+			return err
 		}
-		if rowsAffected == 0 {
-			return storage.ErrFailedStatsInit
+		if attempt >= s.maxTxRetries {
+			return fmt.Errorf("%w: %s", storage.ErrTxConflict, err.Error())
 		}
+		zap.L().Debug("stats transaction serialization failure, retrying", zap.Int("attempt", attempt+1))
+	}
+}
+
+// serializationFailureCode is a postgres error code returned when serializable transaction conflicts with others.
+const serializationFailureCode = "40001"
+
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == serializationFailureCode
+}
+
+// statsTx implements storage.StatsTx over sql transaction.
+type statsTx struct {
+	tx       *sqlx.Tx
+	lockSlot bool
+}
+
+// lock locks the slot row till the end of transaction if it's required by isolation.
+func (t *statsTx) lock(ctx context.Context, slotID string) error {
+	return t.lockSlots(ctx, []string{slotID})
+}
+
+// lockSlots locks the slot rows in id order, so units of work which lock the same slots don't deadlock.
+// Rows are locked with FOR NO KEY UPDATE, it serializes units of work but doesn't block
+// foreign key checks of concurrent inserts to slot_banners and banner_stats.
+func (t *statsTx) lockSlots(ctx context.Context, slotIDs []string) error {
+	if !t.lockSlot {
+		return nil
+	}
+	query, args, err := sqlx.In(
+		"SELECT slot_id FROM slots WHERE slot_id IN (?) ORDER BY slot_id FOR NO KEY UPDATE",
+		slotIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("error during sql query building: %w", err)
+	}
+	var locked []string
+	if err := t.tx.SelectContext(ctx, &locked, t.tx.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to lock slots: %w", err)
+	}
+	unique := make(map[string]struct{}, len(slotIDs))
+	for _, slotID := range slotIDs {
+		unique[slotID] = struct{}{}
+	}
+	if len(locked) < len(unique) {
+		return storage.ErrSlotNotFound
 	}
 	return nil
 }
 
+func (t *statsTx) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	if err := t.lock(ctx, slotID); err != nil {
+		return nil, err
+	}
+	return findSlotBannerStats(ctx, t.tx, slotID, groupID)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	if err := t.lock(ctx, slotID); err != nil {
+		return nil, err
+	}
+	return findSlotBannerStatsSince(ctx, t.tx, slotID, groupID, since)
+}
+
+func (t *statsTx) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	return persistShow(ctx, t.tx, slotID, groupID, bannerID)
+}
+
+func (t *statsTx) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	return persistShows(ctx, t.tx, groupID, slotBanners(slotID, bannerIDs))
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	if err := t.lockSlots(ctx, slotIDs); err != nil {
+		return nil, err
+	}
+	return findSlotsBannerStats(ctx, t.tx, slotIDs, groupID)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	if err := t.lockSlots(ctx, slotIDs); err != nil {
+		return nil, err
+	}
+	return findSlotsBannerStatsSince(ctx, t.tx, slotIDs, groupID, since)
+}
+
+func (t *statsTx) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	return persistShows(ctx, t.tx, groupID, shows)
+}
+
 func (s *Storage) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStats(ctx, s.db, slotID, groupID)
+}
+
+//nolint:lll
+func findSlotBannerStats(ctx context.Context, q sqlx.ExtContext, slotID, groupID string) ([]storage.SlotBannerStat, error) {
 	query := `SELECT sb.banner_id, clicks_amount, shows_amount
 			  FROM (select slot_id, banner_id
 		  			FROM slot_banners
@@ -339,7 +932,7 @@ func (s *Storage) FindSlotBannerStats(ctx context.Context, slotID, groupID strin
 			  ) as sb
 			  left join banner_stats bs
 			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id AND group_id = :groupId`
-	rows, err := s.db.NamedQueryContext(ctx, query, map[string]interface{}{
+	rows, err := sqlx.NamedQueryContext(ctx, q, query, map[string]interface{}{
 		"slotId":  slotID,
 		"groupId": groupID,
 	})
@@ -365,3 +958,202 @@ func (s *Storage) FindSlotBannerStats(ctx context.Context, slotID, groupID strin
 	}
 	return stats, nil
 }
+
+// FindSlotBannerStatsSince returns stats of the slot banners for a group collected since the provided time.
+// Stats are stored in hour buckets, so the bucket which contains since time is included.
+//
+//nolint:lll
+func (s *Storage) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStatsSince(ctx, s.db, slotID, groupID, since)
+}
+
+//nolint:lll
+func findSlotBannerStatsSince(ctx context.Context, q sqlx.ExtContext, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	query := `SELECT sb.banner_id, SUM(bs.clicks_amount) AS clicks_amount, SUM(bs.shows_amount) AS shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats_buckets bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id
+			  	AND bs.group_id = :groupId AND bs.bucket_start >= date_trunc('hour', CAST(:since AS timestamptz))
+			  WHERE sb.slot_id = :slotId
+			  GROUP BY sb.banner_id`
+	rows, err := sqlx.NamedQueryContext(ctx, q, query, map[string]interface{}{
+		"slotId":  slotID,
+		"groupId": groupID,
+		"since":   since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			zap.L().Error("errors during rows closing", zap.Error(err))
+		}
+	}()
+
+	var stats []storage.SlotBannerStat
+	var bannerStat storage.SlotBannerStat
+	for rows.Next() {
+		if err := rows.StructScan(&bannerStat); err != nil {
+			return nil, fmt.Errorf("sql error FindSlotBannerStatsSince result parsing: %w", err)
+		}
+		stats = append(stats, bannerStat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql error FindSlotBannerStatsSince result parsing: %w", err)
+	}
+	return stats, nil
+}
+
+// ListSlots returns up to limit slots with id greater than afterID ordered by id.
+func (s *Storage) ListSlots(ctx context.Context, afterID string, limit int) ([]storage.Slot, error) {
+	var slots []storage.Slot
+	query := "SELECT slot_id, slot_description FROM slots"
+	if err := s.listAfter(ctx, &slots, query, "slot_id", afterID, limit); err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// ListBanners returns up to limit banners with id greater than afterID ordered by id.
+func (s *Storage) ListBanners(ctx context.Context, afterID string, limit int) ([]storage.Banner, error) {
+	var banners []storage.Banner
+	query := "SELECT banner_id, banner_description FROM banners"
+	if err := s.listAfter(ctx, &banners, query, "banner_id", afterID, limit); err != nil {
+		return nil, err
+	}
+	return banners, nil
+}
+
+// ListGroups returns up to limit social groups with id greater than afterID ordered by id.
+func (s *Storage) ListGroups(ctx context.Context, afterID string, limit int) ([]storage.SocialGroup, error) {
+	var groups []storage.SocialGroup
+	query := "SELECT group_id, group_description FROM social_groups"
+	if err := s.listAfter(ctx, &groups, query, "group_id", afterID, limit); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// listAfter selects page of query results ordered by id column, empty afterID selects the first page.
+//
+//nolint:lll
+func (s *Storage) listAfter(ctx context.Context, dest interface{}, query, idColumn, afterID string, limit int) error {
+	args := []interface{}{limit}
+	if afterID != "" {
+		query += " WHERE " + idColumn + " > $2"
+		args = append(args, afterID)
+	}
+	query += " ORDER BY " + idColumn + " LIMIT $1"
+	if err := s.db.SelectContext(ctx, dest, query, args...); err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	return nil
+}
+
+// FindSlotBanners returns banners which rotate in the slot.
+func (s *Storage) FindSlotBanners(ctx context.Context, slotID string) ([]storage.Banner, error) {
+	query := `SELECT b.banner_id, b.banner_description
+			  FROM slot_banners sb
+			  JOIN banners b ON b.banner_id = sb.banner_id
+			  WHERE sb.slot_id = $1
+			  ORDER BY b.banner_id`
+	var banners []storage.Banner
+	if err := s.db.SelectContext(ctx, &banners, query, slotID); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return banners, nil
+}
+
+// FindStatsBuckets returns hour buckets of banners stats matched by the filter ordered by time.
+//
+//nolint:lll
+func (s *Storage) FindStatsBuckets(ctx context.Context, filter storage.StatsBucketsFilter) ([]storage.StatsBucket, error) {
+	conditions := []string{"bucket_start >= date_trunc('hour', CAST(:from AS timestamptz))"}
+	args := map[string]interface{}{"from": filter.From}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "bucket_start < :to")
+		args["to"] = filter.To
+	}
+	if filter.SlotID != "" {
+		conditions = append(conditions, "slot_id = :slotId")
+		args["slotId"] = filter.SlotID
+	}
+	if filter.GroupID != "" {
+		conditions = append(conditions, "group_id = :groupId")
+		args["groupId"] = filter.GroupID
+	}
+	if filter.BannerID != "" {
+		conditions = append(conditions, "banner_id = :bannerId")
+		args["bannerId"] = filter.BannerID
+	}
+	query := `SELECT slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount
+			  FROM banner_stats_buckets
+			  WHERE ` + strings.Join(conditions, " AND ") + `
+			  ORDER BY bucket_start, slot_id, group_id, banner_id`
+	query, namedArgs, err := sqlx.Named(query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	var buckets []storage.StatsBucket
+	if err := s.db.SelectContext(ctx, &buckets, s.db.Rebind(query), namedArgs...); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return buckets, nil
+}
+
+// FindSlotsBannerStats returns banners stats for a group in all provided slots with one query.
+// Result is grouped by slot id.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStats(ctx, s.db, slotIDs, groupID)
+}
+
+//nolint:lll
+func findSlotsBannerStats(ctx context.Context, q sqlx.ExtContext, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	query, args, err := sqlx.In(`SELECT sb.slot_id, sb.banner_id, clicks_amount, shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id AND bs.group_id = ?
+			  WHERE sb.slot_id IN (?)`, groupID, slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	return selectSlotsBannerStats(ctx, q, q.Rebind(query), args)
+}
+
+// FindSlotsBannerStatsSince returns banners stats for a group in all provided slots collected since the provided time.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStatsSince(ctx, s.db, slotIDs, groupID, since)
+}
+
+//nolint:lll
+func findSlotsBannerStatsSince(ctx context.Context, q sqlx.ExtContext, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	query, args, err := sqlx.In(`SELECT sb.slot_id, sb.banner_id,
+			  	SUM(bs.clicks_amount) AS clicks_amount, SUM(bs.shows_amount) AS shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats_buckets bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id
+			  	AND bs.group_id = ? AND bs.bucket_start >= date_trunc('hour', CAST(? AS timestamptz))
+			  WHERE sb.slot_id IN (?)
+			  GROUP BY sb.slot_id, sb.banner_id`, groupID, since, slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	return selectSlotsBannerStats(ctx, q, q.Rebind(query), args)
+}
+
+//nolint:lll
+func selectSlotsBannerStats(ctx context.Context, q sqlx.QueryerContext, query string, args []interface{}) (map[string][]storage.SlotBannerStat, error) {
+	var bannerStats []storage.SlotBannerStat
+	if err := sqlx.SelectContext(ctx, q, &bannerStats, query, args...); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	stats := make(map[string][]storage.SlotBannerStat)
+	for _, bannerStat := range bannerStats {
+		stats[bannerStat.SlotID] = append(stats[bannerStat.SlotID], bannerStat)
+	}
+	return stats, nil
+}
@@ -0,0 +1,279 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/Raschudesny/otus_project/v1/internal/storage/sql"
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/stretchr/testify/require"
+)
+
+// testDSNEnv is a name of env variable with DSN of the migrated database used by storage tests.
+const testDSNEnv = "ROTATION_TEST_DSN"
+
+func newTestStorage(t *testing.T, isolation string) *sql.Storage {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set, storage tests are skipped", testDSNEnv)
+	}
+	s := sql.NewStorage("pgx", config.DBConfig{
+		MaxOpenConnections:    20,
+		MaxIdleConnections:    5,
+		MaxConnectionLifetime: time.Minute,
+		DSN:                   dsn,
+		Isolation:             isolation,
+		MaxTxRetries:          100,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	require.NoError(t, s.Connect(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	return s
+}
+
+func newTestSlotBanner(ctx context.Context, t *testing.T, s *sql.Storage) (slotID, groupID, bannerID string) {
+	t.Helper()
+	slotID, err := s.AddSlot(ctx, "concurrent slot")
+	require.NoError(t, err)
+	bannerID, err = s.AddBanner(ctx, "concurrent banner")
+	require.NoError(t, err)
+	groupID, err = s.AddGroup(ctx, "concurrent group")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, slotID, bannerID))
+	return slotID, groupID, bannerID
+}
+
+func TestConcurrentShowsAndClicks(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	err := s.PersistClick(ctx, slotID, groupID, bannerID)
+	require.True(t, errors.Is(err, storage.ErrBannerNotShown))
+
+	const workers = 50
+	wg := sync.WaitGroup{}
+	errs := make(chan error, workers*2)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.PersistShow(ctx, slotID, groupID, bannerID)
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.PersistClick(ctx, slotID, groupID, bannerID)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	stats, err := s.FindSlotBannerStats(ctx, slotID, groupID)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, bannerID, stats[0].BannerID)
+	require.Equal(t, int64(workers), stats[0].ShowAmount.Int64)
+	require.Equal(t, int64(workers), stats[0].ClickAmount.Int64)
+}
+
+// TestStatsTxIsolation checks that concurrent units of work never read the same stats.
+func TestStatsTxIsolation(t *testing.T) {
+	for _, isolation := range []string{storage.IsolationLock, storage.IsolationOptimistic} {
+		isolation := isolation
+		t.Run(isolation, func(t *testing.T) {
+			s := newTestStorage(t, isolation)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+			defer cancel()
+			slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+			const workers = 20
+			wg := sync.WaitGroup{}
+			mu := sync.Mutex{}
+			seenShows := make(map[int64]struct{})
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var shows int64
+					err := s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+						stats, err := tx.FindSlotBannerStats(ctx, slotID, groupID)
+						if err != nil {
+							return err
+						}
+						shows = stats[0].GetShows()
+						return tx.PersistShow(ctx, slotID, groupID, bannerID)
+					})
+					require.NoError(t, err)
+					mu.Lock()
+					seenShows[shows] = struct{}{}
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+			require.Len(t, seenShows, workers)
+		})
+	}
+}
+
+// TestSlotsStatsTxIsolation checks that concurrent units of work over several slots
+// never read the same stats and don't deadlock whatever the order of requested slots is.
+func TestSlotsStatsTxIsolation(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	firstSlotID, groupID, firstBannerID := newTestSlotBanner(ctx, t, s)
+	secondSlotID, err := s.AddSlot(ctx, "concurrent slot")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, secondSlotID, firstBannerID))
+
+	const workers = 20
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	seenShows := make(map[int64]struct{})
+	for i := 0; i < workers; i++ {
+		slotIDs := []string{firstSlotID, secondSlotID}
+		if i%2 == 0 {
+			slotIDs = []string{secondSlotID, firstSlotID}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shows int64
+			err := s.InStatsTx(ctx, func(tx storage.StatsTx) error {
+				stats, err := tx.FindSlotsBannerStats(ctx, slotIDs, groupID)
+				if err != nil {
+					return err
+				}
+				shows = stats[firstSlotID][0].GetShows()
+				return tx.PersistSlotsShows(ctx, groupID, []storage.SlotBanner{
+					{SlotID: slotIDs[0], BannerID: firstBannerID},
+					{SlotID: slotIDs[1], BannerID: firstBannerID},
+				})
+			})
+			require.NoError(t, err)
+			mu.Lock()
+			seenShows[shows] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seenShows, workers)
+}
+
+// TestPersistSlotsShows checks that repeated shows of the same banner are all counted by the batch.
+func TestPersistSlotsShows(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	firstSlotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+	secondSlotID, err := s.AddSlot(ctx, "concurrent slot")
+	require.NoError(t, err)
+	require.NoError(t, s.AddBannerToSlot(ctx, secondSlotID, bannerID))
+
+	require.NoError(t, s.PersistSlotsShows(ctx, groupID, []storage.SlotBanner{
+		{SlotID: firstSlotID, BannerID: bannerID},
+		{SlotID: secondSlotID, BannerID: bannerID},
+		{SlotID: firstSlotID, BannerID: bannerID},
+	}))
+	stats, err := s.FindSlotsBannerStats(ctx, []string{firstSlotID, secondSlotID}, groupID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats[firstSlotID][0].GetShows())
+	require.Equal(t, int64(1), stats[secondSlotID][0].GetShows())
+	stats, err = s.FindSlotsBannerStatsSince(ctx, []string{firstSlotID, secondSlotID}, groupID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats[firstSlotID][0].GetShows())
+	require.Equal(t, int64(1), stats[secondSlotID][0].GetShows())
+}
+
+func TestAddBannerToSlotTwice(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	slotID, _, bannerID := newTestSlotBanner(ctx, t, s)
+
+	require.True(t, errors.Is(s.AddBannerToSlot(ctx, slotID, bannerID), storage.ErrSlotToBannerRelationExists))
+}
+
+func TestCatalogChangesNotifications(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	changes := make(chan storage.CatalogChange, 10)
+	listenCtx, stopListening := context.WithCancel(ctx)
+	defer stopListening()
+	go s.ListenCatalogChanges(listenCtx, func(change storage.CatalogChange) {
+		changes <- change
+	})
+	// empty change is sent when listening is started
+	require.Equal(t, storage.CatalogChange{}, <-changes)
+
+	slotID, _, bannerID := newTestSlotBanner(ctx, t, s)
+	require.Equal(t, storage.CatalogChange{SlotID: slotID, BannerID: bannerID}, <-changes)
+	require.NoError(t, s.DeleteBannerFromSlot(ctx, slotID, bannerID))
+	require.Equal(t, storage.CatalogChange{SlotID: slotID, BannerID: bannerID}, <-changes)
+}
+
+// TestConcurrentOutboxClaims checks that concurrent relays never claim the same outbox events.
+func TestConcurrentOutboxClaims(t *testing.T) {
+	s := newTestStorage(t, storage.IsolationLock)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	slotID, groupID, bannerID := newTestSlotBanner(ctx, t, s)
+
+	const shows = 100
+	bannerIDs := make([]string, 0, shows)
+	for i := 0; i < shows; i++ {
+		bannerIDs = append(bannerIDs, bannerID)
+	}
+	require.NoError(t, s.PersistShows(ctx, slotID, groupID, bannerIDs))
+
+	const workers = 10
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	claimed := make(map[string]int)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				events, err := s.ClaimOutboxEvents(ctx, 7, time.Minute)
+				require.NoError(t, err)
+				if len(events) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, event := range events {
+					if event.SlotID == slotID {
+						claimed[event.ID]++
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, claimed, shows)
+	for id, times := range claimed {
+		require.Equal(t, 1, times, "event %s is claimed several times", id)
+	}
+}
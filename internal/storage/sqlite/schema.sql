@@ -0,0 +1,91 @@
+-- Schema of migrations directory translated for SQLite.
+-- Ids are generated by the service, time values are stored as unix seconds.
+CREATE TABLE IF NOT EXISTS slots
+(
+    slot_id          text PRIMARY KEY,
+    slot_description text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS banners
+(
+    banner_id          text PRIMARY KEY,
+    banner_description text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS social_groups
+(
+    group_id          text PRIMARY KEY,
+    group_description text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS slot_banners
+(
+    slot_id   text NOT NULL REFERENCES slots (slot_id) ON DELETE CASCADE,
+    banner_id text NOT NULL REFERENCES banners (banner_id) ON DELETE CASCADE,
+    PRIMARY KEY (slot_id, banner_id)
+);
+
+CREATE TABLE IF NOT EXISTS banner_stats
+(
+    slot_id       text    NOT NULL REFERENCES slots (slot_id) ON DELETE CASCADE,
+    group_id      text    NOT NULL REFERENCES social_groups (group_id) ON DELETE CASCADE,
+    banner_id     text    NOT NULL REFERENCES banners (banner_id) ON DELETE CASCADE,
+    clicks_amount integer NOT NULL,
+    shows_amount  integer NOT NULL,
+    PRIMARY KEY (slot_id, group_id, banner_id)
+);
+
+CREATE TABLE IF NOT EXISTS banner_stats_buckets
+(
+    slot_id       text    NOT NULL REFERENCES slots (slot_id) ON DELETE CASCADE,
+    group_id      text    NOT NULL REFERENCES social_groups (group_id) ON DELETE CASCADE,
+    banner_id     text    NOT NULL REFERENCES banners (banner_id) ON DELETE CASCADE,
+    bucket_start  integer NOT NULL,
+    clicks_amount integer NOT NULL DEFAULT 0,
+    shows_amount  integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (slot_id, group_id, banner_id, bucket_start)
+);
+CREATE INDEX IF NOT EXISTS banner_stats_buckets_bucket_start_idx ON banner_stats_buckets (bucket_start);
+
+CREATE TABLE IF NOT EXISTS slot_settings
+(
+    slot_id              text PRIMARY KEY REFERENCES slots (slot_id) ON DELETE CASCADE,
+    strategy             text,
+    exploration_constant real,
+    epsilon              real,
+    epsilon_decay        real,
+    thompson_alpha       real,
+    thompson_beta        real,
+    window_seconds       integer
+);
+
+CREATE TABLE IF NOT EXISTS impression_clicks
+(
+    impression_id text PRIMARY KEY,
+    slot_id       text REFERENCES slots (slot_id) ON DELETE CASCADE,
+    group_id      text REFERENCES social_groups (group_id) ON DELETE CASCADE,
+    banner_id     text REFERENCES banners (banner_id) ON DELETE CASCADE,
+    clicked_at    integer NOT NULL
+);
+CREATE INDEX IF NOT EXISTS impression_clicks_clicked_at_idx ON impression_clicks (clicked_at);
+
+CREATE TABLE IF NOT EXISTS click_idempotency_keys
+(
+    idempotency_key text PRIMARY KEY,
+    created_at      integer NOT NULL
+);
+CREATE INDEX IF NOT EXISTS click_idempotency_keys_created_at_idx ON click_idempotency_keys (created_at);
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    seq          integer PRIMARY KEY AUTOINCREMENT,
+    event_id     text    NOT NULL UNIQUE,
+    event_type   text    NOT NULL,
+    slot_id      text    NOT NULL,
+    group_id     text    NOT NULL,
+    banner_id    text    NOT NULL,
+    created_at   integer NOT NULL,
+    locked_until integer,
+    sent_at      integer
+);
+CREATE INDEX IF NOT EXISTS outbox_sent_at_idx ON outbox (sent_at);
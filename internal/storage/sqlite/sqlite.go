@@ -0,0 +1,921 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed" // schema is embedded to run service as a single binary
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Raschudesny/otus_project/v1/internal/config"
+	"github.com/Raschudesny/otus_project/v1/internal/storage"
+	"github.com/jmoiron/sqlx"
+	sqldblogger "github.com/simukti/sqldb-logger"
+	"github.com/simukti/sqldb-logger/logadapter/zapadapter"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // pure go sqlite driver, no cgo is required
+)
+
+const driverName = "sqlite"
+
+// connectionPragmas are applied to each connection: foreign keys are required for cascade deletes
+// and busy timeout makes writers wait for each other instead of failing.
+const connectionPragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+
+//go:embed schema.sql
+var schema string
+
+func init() {
+	sqlx.BindDriver(driverName, sqlx.QUESTION)
+}
+
+// Storage is a repository over embedded SQLite database file.
+// SQLite allows only one writer at a time, so storage uses one connection
+// and all transactions (including units of work) are serialized.
+type Storage struct {
+	db  *sqlx.DB
+	dsn string
+	now func() time.Time
+}
+
+// NewStorage creates storage of the SQLite database, DSN is a database file (e.g. "file:rotation.db").
+func NewStorage(cnf config.DBConfig) *Storage {
+	return &Storage{
+		dsn: cnf.DSN,
+		now: time.Now,
+	}
+}
+
+// Connect opens database file and creates schema if it doesn't exist yet.
+func (s *Storage) Connect(ctx context.Context) error {
+	dsn := s.dsn
+	if strings.Contains(dsn, "?") {
+		dsn += "&" + connectionPragmas
+	} else {
+		dsn += "?" + connectionPragmas
+	}
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	loggerAdapter := zapadapter.New(zap.L())
+	db = sqldblogger.OpenDriver(dsn, db.Driver(), loggerAdapter)
+	db.SetMaxOpenConns(1)
+
+	s.db = sqlx.NewDb(db, driverName)
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to db: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to create db schema: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) Close() error {
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("error during db connection closing: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) AddSlot(ctx context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	query := "INSERT INTO slots (slot_id, slot_description) VALUES (?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, id, description); err != nil {
+		return "", fmt.Errorf("sql execution error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *Storage) GetSlotByID(ctx context.Context, id string) (storage.Slot, error) {
+	query := "SELECT slot_id, slot_description FROM slots WHERE slot_id = ?"
+	slot := new(storage.Slot)
+	err := s.db.GetContext(ctx, slot, query, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.Slot{}, storage.ErrSlotNotFound
+	case err != nil:
+		return storage.Slot{}, fmt.Errorf("sql GetSlotById error: %w", err)
+	default:
+		return *slot, nil
+	}
+}
+
+// UpdateSlot updates description of the slot.
+func (s *Storage) UpdateSlot(ctx context.Context, slot storage.Slot) error {
+	query := "UPDATE slots SET slot_description = ? WHERE slot_id = ?"
+	return s.updateByID(ctx, query, storage.ErrSlotNotFound, slot.Description, slot.ID)
+}
+
+func (s *Storage) DeleteSlot(ctx context.Context, id string) error {
+	return s.deleteByID(ctx, "DELETE FROM slots WHERE slot_id = ?", id, storage.ErrSlotNotFound)
+}
+
+func (s *Storage) GetSlotSettings(ctx context.Context, slotID string) (storage.SlotSettings, error) {
+	query := `SELECT s.slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds
+			  FROM slots s
+			  LEFT JOIN slot_settings ss ON s.slot_id = ss.slot_id
+			  WHERE s.slot_id = ?`
+	settings := new(storage.SlotSettings)
+	err := s.db.GetContext(ctx, settings, query, slotID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.SlotSettings{}, storage.ErrSlotNotFound
+	case err != nil:
+		return storage.SlotSettings{}, fmt.Errorf("sql GetSlotSettings error: %w", err)
+	default:
+		return *settings, nil
+	}
+}
+
+// GetSlotsSettings returns settings of all existing slots from the provided list.
+func (s *Storage) GetSlotsSettings(ctx context.Context, slotIDs []string) ([]storage.SlotSettings, error) {
+	query, args, err := sqlx.In(`SELECT s.slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds
+			  FROM slots s
+			  LEFT JOIN slot_settings ss ON s.slot_id = ss.slot_id
+			  WHERE s.slot_id IN (?)`, slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	var settings []storage.SlotSettings
+	if err := s.db.SelectContext(ctx, &settings, query, args...); err != nil {
+		return nil, fmt.Errorf("sql execution error: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *Storage) SetSlotSettings(ctx context.Context, settings storage.SlotSettings) error {
+	// WHERE clause is required by SQLite to parse upsert after INSERT ... SELECT
+	query := `INSERT INTO slot_settings (slot_id, strategy, exploration_constant, epsilon, epsilon_decay,
+			  	thompson_alpha, thompson_beta, window_seconds)
+			  SELECT slot_id, :strategy, :exploration_constant, :epsilon, :epsilon_decay,
+			  	:thompson_alpha, :thompson_beta, :window_seconds
+			  FROM slots WHERE slot_id = :slot_id
+			  ON CONFLICT (slot_id) DO UPDATE
+			  SET strategy = excluded.strategy,
+			      exploration_constant = excluded.exploration_constant,
+			      epsilon = excluded.epsilon,
+			      epsilon_decay = excluded.epsilon_decay,
+			      thompson_alpha = excluded.thompson_alpha,
+			      thompson_beta = excluded.thompson_beta,
+			      window_seconds = excluded.window_seconds`
+	res, err := s.db.NamedExecContext(ctx, query, settings)
+	if err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during sql rows affected checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrSlotNotFound
+	}
+	return nil
+}
+
+func (s *Storage) AddBanner(ctx context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	query := "INSERT INTO banners (banner_id, banner_description) VALUES (?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, id, description); err != nil {
+		return "", fmt.Errorf("sql execution error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *Storage) GetBannerByID(ctx context.Context, id string) (storage.Banner, error) {
+	query := "SELECT banner_id, banner_description FROM banners WHERE banner_id = ?"
+	banner := new(storage.Banner)
+	err := s.db.GetContext(ctx, banner, query, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.Banner{}, storage.ErrBannerNotFound
+	case err != nil:
+		return storage.Banner{}, fmt.Errorf("sql GetBannerById error: %w", err)
+	default:
+		return *banner, nil
+	}
+}
+
+// UpdateBanner updates description of the banner.
+func (s *Storage) UpdateBanner(ctx context.Context, banner storage.Banner) error {
+	query := "UPDATE banners SET banner_description = ? WHERE banner_id = ?"
+	return s.updateByID(ctx, query, storage.ErrBannerNotFound, banner.Description, banner.ID)
+}
+
+func (s *Storage) DeleteBanner(ctx context.Context, id string) error {
+	return s.deleteByID(ctx, "DELETE FROM banners WHERE banner_id = ?", id, storage.ErrBannerNotFound)
+}
+
+func (s *Storage) AddBannerToSlot(ctx context.Context, slotID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		var slotExists, bannerExists bool
+		query := `SELECT EXISTS (SELECT 1 FROM slots WHERE slot_id = ?),
+				  	EXISTS (SELECT 1 FROM banners WHERE banner_id = ?)`
+		if err := tx.QueryRowxContext(ctx, query, slotID, bannerID).Scan(&slotExists, &bannerExists); err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		if !slotExists {
+			return storage.ErrSlotNotFound
+		}
+		if !bannerExists {
+			return storage.ErrBannerNotFound
+		}
+
+		query = "INSERT INTO slot_banners (slot_id, banner_id) VALUES (?, ?) ON CONFLICT DO NOTHING"
+		res, err := tx.ExecContext(ctx, query, slotID, bannerID)
+		if err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		}
+		if affected == 0 {
+			return storage.ErrSlotToBannerRelationExists
+		}
+		return nil
+	})
+}
+
+func (s *Storage) DeleteBannerFromSlot(ctx context.Context, slotID, bannerID string) error {
+	query := "DELETE FROM slot_banners WHERE slot_id = ? AND banner_id = ?"
+	res, err := s.db.ExecContext(ctx, query, slotID, bannerID)
+	if err != nil {
+		return fmt.Errorf("sql delete banner from slot query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrSlotToBannerRelationNotFound
+	}
+	return nil
+}
+
+func (s *Storage) AddGroup(ctx context.Context, description string) (string, error) {
+	id, err := storage.NewID()
+	if err != nil {
+		return "", err
+	}
+	query := "INSERT INTO social_groups (group_id, group_description) VALUES (?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, id, description); err != nil {
+		return "", fmt.Errorf("sql execution error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *Storage) GetGroupByID(ctx context.Context, groupID string) (storage.SocialGroup, error) {
+	query := "SELECT group_id, group_description FROM social_groups WHERE group_id = ?"
+	group := new(storage.SocialGroup)
+	err := s.db.GetContext(ctx, group, query, groupID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.SocialGroup{}, storage.ErrGroupNotFound
+	case err != nil:
+		return storage.SocialGroup{}, fmt.Errorf("sql GetGroupById error: %w", err)
+	default:
+		return *group, nil
+	}
+}
+
+// UpdateGroup updates description of the social group.
+func (s *Storage) UpdateGroup(ctx context.Context, group storage.SocialGroup) error {
+	query := "UPDATE social_groups SET group_description = ? WHERE group_id = ?"
+	return s.updateByID(ctx, query, storage.ErrGroupNotFound, group.Description, group.ID)
+}
+
+func (s *Storage) DeleteGroup(ctx context.Context, id string) error {
+	return s.deleteByID(ctx, "DELETE FROM social_groups WHERE group_id = ?", id, storage.ErrGroupNotFound)
+}
+
+// deleteByID executes delete query, notFoundErr is returned if nothing was deleted.
+func (s *Storage) deleteByID(ctx context.Context, query, id string, notFoundErr error) error {
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("sql delete operation query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	if affected == 0 {
+		return notFoundErr
+	}
+	return nil
+}
+
+// updateByID executes update query which should affect one row, notFoundErr is returned if no rows are updated.
+func (s *Storage) updateByID(ctx context.Context, query string, notFoundErr error, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("sql update operation query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during rows affected by update checking: %w", err)
+	}
+	if affected == 0 {
+		return notFoundErr
+	}
+	return nil
+}
+
+func (s *Storage) PersistClick(ctx context.Context, slotID, groupID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return s.persistStat(ctx, tx, slotID, groupID, bannerID, 1, 0)
+	})
+}
+
+// PersistImpressionClick stores click of the impression, only the first click of each impression is counted.
+//
+//nolint:lll
+func (s *Storage) PersistImpressionClick(ctx context.Context, impressionID, slotID, groupID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO impression_clicks (impression_id, slot_id, group_id, banner_id, clicked_at)
+				  VALUES (?, ?, ?, ?, ?)
+				  ON CONFLICT (impression_id) DO NOTHING`
+		res, err := tx.ExecContext(ctx, query, impressionID, slotID, groupID, bannerID, s.now().Unix())
+		if err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		}
+		if affected == 0 {
+			return storage.ErrImpressionAlreadyClicked
+		}
+		return s.persistStat(ctx, tx, slotID, groupID, bannerID, 1, 0)
+	})
+}
+
+// DeleteExpiredImpressionClicks removes impression clicks counted before the provided time.
+func (s *Storage) DeleteExpiredImpressionClicks(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM impression_clicks WHERE clicked_at < ?", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("sql delete expired impression clicks query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+// PersistClickOnce stores click with the idempotency key,
+// repeated clicks with the same key are not counted during the dedup window.
+//
+//nolint:lll
+func (s *Storage) PersistClickOnce(ctx context.Context, idempotencyKey, slotID, groupID, bannerID string, window time.Duration) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		now := s.now()
+		query := `INSERT INTO click_idempotency_keys (idempotency_key, created_at)
+				  VALUES (?, ?)
+				  ON CONFLICT (idempotency_key) DO UPDATE
+				  SET created_at = excluded.created_at
+				  WHERE click_idempotency_keys.created_at < ?`
+		res, err := tx.ExecContext(ctx, query, idempotencyKey, now.Unix(), now.Add(-window).Unix())
+		if err != nil {
+			return fmt.Errorf("error during sql execution: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error during sql rows affected checking: %w", err)
+		}
+		if affected == 0 {
+			return storage.ErrDuplicateClick
+		}
+		return s.persistStat(ctx, tx, slotID, groupID, bannerID, 1, 0)
+	})
+}
+
+// DeleteExpiredClickKeys removes idempotency keys created before the provided time.
+func (s *Storage) DeleteExpiredClickKeys(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM click_idempotency_keys WHERE created_at < ?", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("sql delete expired click keys query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+func (s *Storage) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return s.persistStat(ctx, tx, slotID, groupID, bannerID, 0, 1)
+	})
+}
+
+// PersistShows stores shows of several banners in one transaction.
+func (s *Storage) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return s.persistShows(ctx, tx, slotID, groupID, bannerIDs)
+	})
+}
+
+// PersistSlotsShows stores shows of banners in different slots for a group in one transaction.
+func (s *Storage) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return s.persistSlotsShows(ctx, tx, groupID, shows)
+	})
+}
+
+//nolint:lll
+func (s *Storage) persistSlotsShows(ctx context.Context, tx *sqlx.Tx, groupID string, shows []storage.SlotBanner) error {
+	for _, show := range shows {
+		if err := s.persistStat(ctx, tx, show.SlotID, groupID, show.BannerID, 0, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:lll
+func (s *Storage) persistShows(ctx context.Context, tx *sqlx.Tx, slotID, groupID string, bannerIDs []string) error {
+	for _, bannerID := range bannerIDs {
+		if err := s.persistStat(ctx, tx, slotID, groupID, bannerID, 0, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PersistStats applies stats increments in one transaction. Increments of deleted slots, groups or banners
+// and clicks of banners which weren't shown are skipped, so one bad increment doesn't fail the whole batch.
+func (s *Storage) PersistStats(ctx context.Context, deltas []storage.StatsDelta) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		for _, delta := range deltas {
+			if err := s.persistStatsDelta(ctx, tx, delta); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Storage) persistStatsDelta(ctx context.Context, tx *sqlx.Tx, delta storage.StatsDelta) error {
+	query := `INSERT INTO banner_stats (slot_id, group_id, banner_id, clicks_amount, shows_amount)
+			  SELECT s.slot_id, g.group_id, b.banner_id, :clicks, :shows
+			  FROM slots s, banners b, social_groups g
+			  WHERE s.slot_id = :slotId AND b.banner_id = :bannerId AND g.group_id = :groupId
+			  	AND (:shows > 0 OR EXISTS (
+			  		SELECT 1 FROM banner_stats bs
+			  		WHERE bs.slot_id = s.slot_id AND bs.group_id = g.group_id AND bs.banner_id = b.banner_id
+			  	))
+			  ON CONFLICT (slot_id, group_id, banner_id) DO UPDATE
+			  SET clicks_amount = banner_stats.clicks_amount + excluded.clicks_amount,
+			      shows_amount = banner_stats.shows_amount + excluded.shows_amount`
+	args := map[string]interface{}{
+		"slotId":      delta.SlotID,
+		"groupId":     delta.GroupID,
+		"bannerId":    delta.BannerID,
+		"clicks":      delta.Clicks,
+		"shows":       delta.Shows,
+		"bucketStart": delta.BucketStart.Truncate(time.Hour).Unix(),
+	}
+	res, err := tx.NamedExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during sql rows affected checking: %w", err)
+	}
+	if affected == 0 {
+		return nil
+	}
+	if err := persistBucketStat(ctx, tx, args); err != nil {
+		return err
+	}
+	// events of the late flushed increment get the time of its bucket like the bucket row
+	return addOutboxEvents(ctx, tx, delta.SlotID, delta.GroupID, delta.BannerID, delta.Clicks, delta.Shows, delta.BucketStart)
+}
+
+// persistStat adds clicks and shows to banner stats and to the current hour bucket with upserts.
+// Stats row is created only by shows, clicks of the banner which wasn't shown yet are not counted.
+//
+//nolint:lll
+func (s *Storage) persistStat(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string, clicks, shows int) error {
+	// WHERE clause is required by SQLite to parse upsert after INSERT ... SELECT
+	query := `INSERT INTO banner_stats (slot_id, group_id, banner_id, clicks_amount, shows_amount)
+			  SELECT :slotId, :groupId, :bannerId, :clicks, :shows
+			  WHERE :shows > 0 OR EXISTS (
+			  	SELECT 1 FROM banner_stats WHERE slot_id = :slotId AND group_id = :groupId AND banner_id = :bannerId
+			  )
+			  ON CONFLICT (slot_id, group_id, banner_id) DO UPDATE
+			  SET clicks_amount = banner_stats.clicks_amount + excluded.clicks_amount,
+			      shows_amount = banner_stats.shows_amount + excluded.shows_amount`
+	args := map[string]interface{}{
+		"slotId":      slotID,
+		"groupId":     groupID,
+		"bannerId":    bannerID,
+		"clicks":      clicks,
+		"shows":       shows,
+		"bucketStart": s.now().Truncate(time.Hour).Unix(),
+	}
+	res, err := tx.NamedExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("error during sql execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error during sql rows affected checking: %w", err)
+	}
+	if affected == 0 {
+		return storage.ErrBannerNotShown
+	}
+	if err := persistBucketStat(ctx, tx, args); err != nil {
+		return err
+	}
+	return addOutboxEvents(ctx, tx, slotID, groupID, bannerID, int64(clicks), int64(shows), s.now())
+}
+
+// persistBucketStat adds clicks and shows to the hour bucket of banner stats.
+func persistBucketStat(ctx context.Context, tx *sqlx.Tx, args map[string]interface{}) error {
+	query := `INSERT INTO banner_stats_buckets (slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount)
+			  VALUES (:slotId, :groupId, :bannerId, :bucketStart, :clicks, :shows)
+			  ON CONFLICT (slot_id, group_id, banner_id, bucket_start) DO UPDATE
+			  SET clicks_amount = banner_stats_buckets.clicks_amount + excluded.clicks_amount,
+			      shows_amount = banner_stats_buckets.shows_amount + excluded.shows_amount`
+	if _, err := tx.NamedExecContext(ctx, query, args); err != nil {
+		return fmt.Errorf("error during stats bucket sql execution: %w", err)
+	}
+	return nil
+}
+
+// addOutboxEvents stores click and show events of the stats increment created at the provided time to the outbox,
+// one event per click or show.
+//
+//nolint:lll
+func addOutboxEvents(ctx context.Context, tx *sqlx.Tx, slotID, groupID, bannerID string, clicks, shows int64, createdAt time.Time) error {
+	query := `INSERT INTO outbox (event_id, event_type, slot_id, group_id, banner_id, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	for _, events := range []struct {
+		eventType string
+		amount    int64
+	}{{storage.EventTypeClick, clicks}, {storage.EventTypeShow, shows}} {
+		for i := int64(0); i < events.amount; i++ {
+			id, err := storage.NewID()
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, query, id, events.eventType, slotID, groupID, bannerID, createdAt.Unix()); err != nil {
+				return fmt.Errorf("error during outbox events sql execution: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// outboxEvent is a row of the outbox table, time is stored as unix seconds.
+type outboxEvent struct {
+	ID        string `db:"event_id"`
+	Type      string `db:"event_type"`
+	SlotID    string `db:"slot_id"`
+	GroupID   string `db:"group_id"`
+	BannerID  string `db:"banner_id"`
+	CreatedAt int64  `db:"created_at"`
+}
+
+// ClaimOutboxEvents returns up to limit not sent events in the order they were stored
+// and locks them for the lease time. Events which are not marked as sent until the lease end can be claimed again.
+//
+//nolint:lll
+func (s *Storage) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxEvent, error) {
+	var rows []outboxEvent
+	if err := s.inTx(ctx, func(tx *sqlx.Tx) error {
+		now := s.now()
+		query := `SELECT event_id, event_type, slot_id, group_id, banner_id, created_at FROM outbox
+				  WHERE sent_at IS NULL AND (locked_until IS NULL OR locked_until < ?)
+				  ORDER BY seq LIMIT ?`
+		if err := tx.SelectContext(ctx, &rows, query, now.Unix(), limit); err != nil {
+			return fmt.Errorf("sql select outbox events query error: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		query, args, err := sqlx.In("UPDATE outbox SET locked_until = ? WHERE event_id IN (?)", now.Add(lease).Unix(), ids)
+		if err != nil {
+			return fmt.Errorf("error during sql query building: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("sql lock outbox events query error: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	events := make([]storage.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, storage.OutboxEvent{
+			ID:        row.ID,
+			Type:      row.Type,
+			SlotID:    row.SlotID,
+			GroupID:   row.GroupID,
+			BannerID:  row.BannerID,
+			CreatedAt: time.Unix(row.CreatedAt, 0),
+		})
+	}
+	return events, nil
+}
+
+// MarkOutboxEventsSent marks published events as sent.
+func (s *Storage) MarkOutboxEventsSent(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("UPDATE outbox SET sent_at = ? WHERE event_id IN (?)", s.now().Unix(), ids)
+	if err != nil {
+		return fmt.Errorf("error during sql query building: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("sql mark outbox events sent query error: %w", err)
+	}
+	return nil
+}
+
+// DeleteSentOutboxEvents removes events sent before the provided time.
+func (s *Storage) DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM outbox WHERE sent_at < ?", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("sql delete sent outbox events query error: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error during rows affected by delete checking: %w", err)
+	}
+	return affected, nil
+}
+
+// inTx executes fn in a transaction, transaction is committed only if fn returns no error.
+func (s *Storage) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			zap.L().Error("error during transaction rollback", zap.Error(rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// InStatsTx executes fn in a transaction, units of work are serialized by the single db connection.
+func (s *Storage) InStatsTx(ctx context.Context, fn func(tx storage.StatsTx) error) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return fn(&statsTx{s: s, tx: tx})
+	})
+}
+
+// statsTx implements storage.StatsTx over sql transaction.
+type statsTx struct {
+	s  *Storage
+	tx *sqlx.Tx
+}
+
+func (t *statsTx) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStats(ctx, t.tx, slotID, groupID)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStatsSince(ctx, t.tx, slotID, groupID, since)
+}
+
+func (t *statsTx) PersistShow(ctx context.Context, slotID, groupID, bannerID string) error {
+	return t.s.persistStat(ctx, t.tx, slotID, groupID, bannerID, 0, 1)
+}
+
+func (t *statsTx) PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error {
+	return t.s.persistShows(ctx, t.tx, slotID, groupID, bannerIDs)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStats(ctx, t.tx, slotIDs, groupID)
+}
+
+//nolint:lll
+func (t *statsTx) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStatsSince(ctx, t.tx, slotIDs, groupID, since)
+}
+
+func (t *statsTx) PersistSlotsShows(ctx context.Context, groupID string, shows []storage.SlotBanner) error {
+	return t.s.persistSlotsShows(ctx, t.tx, groupID, shows)
+}
+
+func (s *Storage) FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStats(ctx, s.db, slotID, groupID)
+}
+
+// FindSlotBannerStatsSince returns stats of the slot banners for a group collected since the provided time.
+// Stats are stored in hour buckets, so the bucket which contains since time is included.
+//
+//nolint:lll
+func (s *Storage) FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	return findSlotBannerStatsSince(ctx, s.db, slotID, groupID, since)
+}
+
+// ListSlots returns up to limit slots with id greater than afterID ordered by id.
+func (s *Storage) ListSlots(ctx context.Context, afterID string, limit int) ([]storage.Slot, error) {
+	query := `SELECT slot_id, slot_description FROM slots
+			  WHERE slot_id > ? ORDER BY slot_id LIMIT ?`
+	var slots []storage.Slot
+	if err := s.db.SelectContext(ctx, &slots, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return slots, nil
+}
+
+// ListBanners returns up to limit banners with id greater than afterID ordered by id.
+func (s *Storage) ListBanners(ctx context.Context, afterID string, limit int) ([]storage.Banner, error) {
+	query := `SELECT banner_id, banner_description FROM banners
+			  WHERE banner_id > ? ORDER BY banner_id LIMIT ?`
+	var banners []storage.Banner
+	if err := s.db.SelectContext(ctx, &banners, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return banners, nil
+}
+
+// ListGroups returns up to limit social groups with id greater than afterID ordered by id.
+func (s *Storage) ListGroups(ctx context.Context, afterID string, limit int) ([]storage.SocialGroup, error) {
+	query := `SELECT group_id, group_description FROM social_groups
+			  WHERE group_id > ? ORDER BY group_id LIMIT ?`
+	var groups []storage.SocialGroup
+	if err := s.db.SelectContext(ctx, &groups, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return groups, nil
+}
+
+// FindSlotBanners returns banners which rotate in the slot.
+func (s *Storage) FindSlotBanners(ctx context.Context, slotID string) ([]storage.Banner, error) {
+	query := `SELECT b.banner_id, b.banner_description
+			  FROM slot_banners sb
+			  JOIN banners b ON b.banner_id = sb.banner_id
+			  WHERE sb.slot_id = ?
+			  ORDER BY b.banner_id`
+	var banners []storage.Banner
+	if err := s.db.SelectContext(ctx, &banners, query, slotID); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return banners, nil
+}
+
+// FindStatsBuckets returns hour buckets of banners stats matched by the filter ordered by time.
+//
+//nolint:lll
+func (s *Storage) FindStatsBuckets(ctx context.Context, filter storage.StatsBucketsFilter) ([]storage.StatsBucket, error) {
+	conditions := []string{"bucket_start >= ?"}
+	args := []interface{}{filter.From.Truncate(time.Hour).Unix()}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "bucket_start < ?")
+		args = append(args, filter.To.Unix())
+	}
+	if filter.SlotID != "" {
+		conditions = append(conditions, "slot_id = ?")
+		args = append(args, filter.SlotID)
+	}
+	if filter.GroupID != "" {
+		conditions = append(conditions, "group_id = ?")
+		args = append(args, filter.GroupID)
+	}
+	if filter.BannerID != "" {
+		conditions = append(conditions, "banner_id = ?")
+		args = append(args, filter.BannerID)
+	}
+	query := `SELECT slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount
+			  FROM banner_stats_buckets
+			  WHERE ` + strings.Join(conditions, " AND ") + `
+			  ORDER BY bucket_start, slot_id, group_id, banner_id`
+	// bucket start is stored as unix time
+	var rows []struct {
+		storage.StatsBucket
+		BucketStart int64 `db:"bucket_start"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	buckets := make([]storage.StatsBucket, 0, len(rows))
+	for _, row := range rows {
+		bucket := row.StatsBucket
+		bucket.BucketStart = time.Unix(row.BucketStart, 0)
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// FindSlotsBannerStats returns banners stats for a group in all provided slots with one query.
+// Result is grouped by slot id.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStats(ctx, s.db, slotIDs, groupID)
+}
+
+//nolint:lll
+func findSlotsBannerStats(ctx context.Context, q sqlx.QueryerContext, slotIDs []string, groupID string) (map[string][]storage.SlotBannerStat, error) {
+	query, args, err := sqlx.In(`SELECT sb.slot_id, sb.banner_id, clicks_amount, shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id AND bs.group_id = ?
+			  WHERE sb.slot_id IN (?)
+			  ORDER BY sb.rowid`, groupID, slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	return selectSlotsBannerStats(ctx, q, query, args)
+}
+
+// FindSlotsBannerStatsSince returns banners stats for a group in all provided slots collected since the provided time.
+//
+//nolint:lll
+func (s *Storage) FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	return findSlotsBannerStatsSince(ctx, s.db, slotIDs, groupID, since)
+}
+
+//nolint:lll
+func findSlotsBannerStatsSince(ctx context.Context, q sqlx.QueryerContext, slotIDs []string, groupID string, since time.Time) (map[string][]storage.SlotBannerStat, error) {
+	query, args, err := sqlx.In(`SELECT sb.slot_id, sb.banner_id,
+			  	SUM(bs.clicks_amount) AS clicks_amount, SUM(bs.shows_amount) AS shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats_buckets bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id
+			  	AND bs.group_id = ? AND bs.bucket_start >= ?
+			  WHERE sb.slot_id IN (?)
+			  GROUP BY sb.slot_id, sb.banner_id
+			  ORDER BY MIN(sb.rowid)`, groupID, since.Truncate(time.Hour).Unix(), slotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error during sql query building: %w", err)
+	}
+	return selectSlotsBannerStats(ctx, q, query, args)
+}
+
+//nolint:lll
+func selectSlotsBannerStats(ctx context.Context, q sqlx.QueryerContext, query string, args []interface{}) (map[string][]storage.SlotBannerStat, error) {
+	var bannerStats []storage.SlotBannerStat
+	if err := sqlx.SelectContext(ctx, q, &bannerStats, query, args...); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	stats := make(map[string][]storage.SlotBannerStat)
+	for _, bannerStat := range bannerStats {
+		stats[bannerStat.SlotID] = append(stats[bannerStat.SlotID], bannerStat)
+	}
+	return stats, nil
+}
+
+//nolint:lll
+func findSlotBannerStats(ctx context.Context, q sqlx.QueryerContext, slotID, groupID string) ([]storage.SlotBannerStat, error) {
+	query := `SELECT sb.slot_id, sb.banner_id, clicks_amount, shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id AND bs.group_id = ?
+			  WHERE sb.slot_id = ?
+			  ORDER BY sb.rowid`
+	var stats []storage.SlotBannerStat
+	if err := sqlx.SelectContext(ctx, q, &stats, query, groupID, slotID); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return stats, nil
+}
+
+//nolint:lll
+func findSlotBannerStatsSince(ctx context.Context, q sqlx.QueryerContext, slotID, groupID string, since time.Time) ([]storage.SlotBannerStat, error) {
+	query := `SELECT sb.slot_id, sb.banner_id, SUM(bs.clicks_amount) AS clicks_amount, SUM(bs.shows_amount) AS shows_amount
+			  FROM slot_banners sb
+			  LEFT JOIN banner_stats_buckets bs
+			  ON sb.slot_id = bs.slot_id AND sb.banner_id = bs.banner_id
+			  	AND bs.group_id = ? AND bs.bucket_start >= ?
+			  WHERE sb.slot_id = ?
+			  GROUP BY sb.banner_id
+			  ORDER BY MIN(sb.rowid)`
+	var stats []storage.SlotBannerStat
+	if err := sqlx.SelectContext(ctx, q, &stats, query, groupID, since.Truncate(time.Hour).Unix(), slotID); err != nil {
+		return nil, fmt.Errorf("error during sql execution: %w", err)
+	}
+	return stats, nil
+}
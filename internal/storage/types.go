@@ -1,8 +1,23 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"time"
+)
+
+// Types of the stats events.
+const (
+	EventTypeClick = "click"
+	EventTypeShow  = "show"
+)
+
+const (
+	// IsolationLock locks the slot row before its stats are read, so units of work in the same slot are serialized.
+	IsolationLock = "lock"
+	// IsolationOptimistic runs unit of work in serializable transaction and retries it on serialization failure.
+	IsolationOptimistic = "optimistic"
 )
 
 var (
@@ -10,9 +25,13 @@ var (
 	ErrBannerNotFound               = errors.New("banner not found")
 	ErrGroupNotFound                = errors.New("social group not found")
 	ErrSlotToBannerRelationNotFound = errors.New("slot to banner mapping not found")
-	ErrFailedStatsInit              = errors.New("failed to init banner stats")
+	ErrSlotToBannerRelationExists   = errors.New("banner is already added to the slot")
 	ErrBannerNotShown               = errors.New("banner still wasn't shown")
 	ErrNoBannersFoundForSlot        = errors.New("no banners found for provided slot")
+	ErrImpressionAlreadyClicked     = errors.New("click for this impression is already counted")
+	ErrDuplicateClick               = errors.New("click with this idempotency key is already counted")
+	ErrUnknownIsolation             = errors.New("unknown transaction isolation")
+	ErrTxConflict                   = errors.New("transaction conflicts with concurrent transactions")
 )
 
 type Banner struct {
@@ -31,11 +50,19 @@ type SocialGroup struct {
 }
 
 type SlotBannerStat struct {
+	SlotID      string        `db:"slot_id"`
+	GroupID     string        `db:"group_id"`
 	BannerID    string        `db:"banner_id"`
 	ClickAmount sql.NullInt64 `db:"clicks_amount"`
 	ShowAmount  sql.NullInt64 `db:"shows_amount"`
 }
 
+// SlotBanner is a banner chosen for a slot.
+type SlotBanner struct {
+	SlotID   string
+	BannerID string
+}
+
 func (s SlotBannerStat) GetClicks() int64 {
 	if !s.ClickAmount.Valid {
 		return 0
@@ -49,3 +76,99 @@ func (s SlotBannerStat) GetShows() int64 {
 	}
 	return s.ShowAmount.Int64
 }
+
+// GetCTR returns click-through rate, it's zero if banner wasn't shown.
+func (s SlotBannerStat) GetCTR() float64 {
+	if s.GetShows() == 0 {
+		return 0
+	}
+	return float64(s.GetClicks()) / float64(s.GetShows())
+}
+
+// StatsDelta is an increment of banner stats collected during the hour which starts at BucketStart.
+type StatsDelta struct {
+	SlotID      string
+	GroupID     string
+	BannerID    string
+	BucketStart time.Time
+	Clicks      int64
+	Shows       int64
+}
+
+// StatsBucket is banner stats collected during the hour which starts at BucketStart.
+type StatsBucket struct {
+	SlotID      string    `db:"slot_id"`
+	GroupID     string    `db:"group_id"`
+	BannerID    string    `db:"banner_id"`
+	BucketStart time.Time `db:"bucket_start"`
+	Clicks      int64     `db:"clicks_amount"`
+	Shows       int64     `db:"shows_amount"`
+}
+
+// StatsBucketsFilter selects stats buckets which start in [From, To) range, From is truncated to the hour.
+// Zero To means no upper bound. Empty slot, group or banner id matches any of them.
+type StatsBucketsFilter struct {
+	SlotID   string
+	GroupID  string
+	BannerID string
+	From     time.Time
+	To       time.Time
+}
+
+// OutboxEvent is a click or show event stored in the same transaction as the stats update,
+// it's published to the stats queue later and marked as sent.
+type OutboxEvent struct {
+	ID        string    `db:"event_id"`
+	Type      string    `db:"event_type"`
+	SlotID    string    `db:"slot_id"`
+	GroupID   string    `db:"group_id"`
+	BannerID  string    `db:"banner_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// CatalogChange describes changed slot banners or deleted social group.
+// Empty change means that anything could be changed.
+//
+//nolint:tagliatelle // notifications are built by the database trigger with snake_case keys
+type CatalogChange struct {
+	SlotID   string `json:"slot_id"`
+	BannerID string `json:"banner_id"`
+	GroupID  string `json:"group_id"`
+}
+
+// SlotSettings are slot specific rotation settings, not valid values mean that service defaults should be used.
+type SlotSettings struct {
+	SlotID              string          `db:"slot_id"`
+	Strategy            sql.NullString  `db:"strategy"`
+	ExplorationConstant sql.NullFloat64 `db:"exploration_constant"`
+	Epsilon             sql.NullFloat64 `db:"epsilon"`
+	EpsilonDecay        sql.NullFloat64 `db:"epsilon_decay"`
+	ThompsonAlpha       sql.NullFloat64 `db:"thompson_alpha"`
+	ThompsonBeta        sql.NullFloat64 `db:"thompson_beta"`
+	WindowSeconds       sql.NullInt64   `db:"window_seconds"`
+}
+
+// IsEmpty returns true if no slot specific settings are set.
+func (s SlotSettings) IsEmpty() bool {
+	return !s.Strategy.Valid &&
+		!s.ExplorationConstant.Valid &&
+		!s.Epsilon.Valid &&
+		!s.EpsilonDecay.Valid &&
+		!s.ThompsonAlpha.Valid &&
+		!s.ThompsonBeta.Valid &&
+		!s.WindowSeconds.Valid
+}
+
+// StatsTx is a unit of work in which banner stats are read and shows are stored consistently.
+// Stats of several slots are read with slots locked in id order.
+//
+//nolint:lll
+type StatsTx interface {
+	FindSlotBannerStats(ctx context.Context, slotID, groupID string) ([]SlotBannerStat, error)
+	FindSlotBannerStatsSince(ctx context.Context, slotID, groupID string, since time.Time) ([]SlotBannerStat, error)
+	FindSlotsBannerStats(ctx context.Context, slotIDs []string, groupID string) (map[string][]SlotBannerStat, error)
+	FindSlotsBannerStatsSince(ctx context.Context, slotIDs []string, groupID string, since time.Time) (map[string][]SlotBannerStat, error)
+	PersistShow(ctx context.Context, slotID, groupID, bannerID string) error
+	PersistShows(ctx context.Context, slotID, groupID string, bannerIDs []string) error
+	PersistSlotsShows(ctx context.Context, groupID string, shows []SlotBanner) error
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE banner_stats_buckets
+(
+    slot_id       uuid REFERENCES slots (slot_id) ON DELETE CASCADE,
+    group_id      uuid REFERENCES social_groups (group_id) ON DELETE CASCADE,
+    banner_id     uuid REFERENCES banners (banner_id) ON DELETE CASCADE,
+    bucket_start  timestamptz NOT NULL,
+    clicks_amount int NOT NULL DEFAULT 0,
+    shows_amount  int NOT NULL DEFAULT 0,
+    CONSTRAINT banner_stats_buckets_pkey PRIMARY KEY (slot_id, group_id, banner_id, bucket_start)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists banner_stats_buckets;
+-- +goose StatementEnd
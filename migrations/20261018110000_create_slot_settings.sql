@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE slot_settings
+(
+    slot_id              uuid REFERENCES slots (slot_id) ON DELETE CASCADE PRIMARY KEY,
+    strategy             text,
+    exploration_constant double precision,
+    epsilon              double precision,
+    epsilon_decay        double precision,
+    thompson_alpha       double precision,
+    thompson_beta        double precision,
+    window_seconds       bigint
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists slot_settings;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE impression_clicks
+(
+    impression_id uuid PRIMARY KEY,
+    slot_id       uuid REFERENCES slots (slot_id) ON DELETE CASCADE,
+    group_id      uuid REFERENCES social_groups (group_id) ON DELETE CASCADE,
+    banner_id     uuid REFERENCES banners (banner_id) ON DELETE CASCADE,
+    clicked_at    timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists impression_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE click_idempotency_keys
+(
+    idempotency_key text PRIMARY KEY,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX click_idempotency_keys_created_at_idx ON click_idempotency_keys (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists click_idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION notify_slot_banners_change() RETURNS trigger AS
+$$
+DECLARE
+    changed slot_banners;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+    PERFORM pg_notify('rotation_catalog_changes',
+                      json_build_object('slot_id', changed.slot_id, 'banner_id', changed.banner_id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION notify_social_groups_change() RETURNS trigger AS
+$$
+BEGIN
+    PERFORM pg_notify('rotation_catalog_changes', json_build_object('group_id', OLD.group_id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- deleted slots and banners are removed from slot_banners by cascade, so they are notified too
+CREATE TRIGGER slot_banners_change_notify
+    AFTER INSERT OR DELETE
+    ON slot_banners
+    FOR EACH ROW
+EXECUTE FUNCTION notify_slot_banners_change();
+
+CREATE TRIGGER social_groups_change_notify
+    AFTER DELETE
+    ON social_groups
+    FOR EACH ROW
+EXECUTE FUNCTION notify_social_groups_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists social_groups_change_notify on social_groups;
+drop trigger if exists slot_banners_change_notify on slot_banners;
+drop function if exists notify_social_groups_change();
+drop function if exists notify_slot_banners_change();
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX banner_stats_buckets_bucket_start_idx ON banner_stats_buckets (bucket_start);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists banner_stats_buckets_bucket_start_idx;
+-- +goose StatementEnd
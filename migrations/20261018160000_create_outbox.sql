@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox
+(
+    event_id     uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    seq          bigserial   NOT NULL,
+    event_type   text        NOT NULL,
+    slot_id      uuid        NOT NULL,
+    group_id     uuid        NOT NULL,
+    banner_id    uuid        NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    locked_until timestamptz,
+    sent_at      timestamptz
+);
+CREATE INDEX outbox_unsent_seq_idx ON outbox (seq) WHERE sent_at IS NULL;
+CREATE INDEX outbox_sent_at_idx ON outbox (sent_at) WHERE sent_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists outbox;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX impression_clicks_clicked_at_idx ON impression_clicks (clicked_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists impression_clicks_clicked_at_idx;
+-- +goose StatementEnd
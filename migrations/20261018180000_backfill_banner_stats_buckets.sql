@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- stats collected before buckets were created are moved to the epoch bucket,
+-- so stats summed from buckets match the lifetime stats
+INSERT INTO banner_stats_buckets (slot_id, group_id, banner_id, bucket_start, clicks_amount, shows_amount)
+SELECT bs.slot_id,
+       bs.group_id,
+       bs.banner_id,
+       to_timestamp(0),
+       GREATEST(bs.clicks_amount - COALESCE(b.clicks_amount, 0), 0),
+       GREATEST(bs.shows_amount - COALESCE(b.shows_amount, 0), 0)
+FROM banner_stats bs
+         LEFT JOIN (SELECT slot_id, group_id, banner_id, SUM(clicks_amount) AS clicks_amount, SUM(shows_amount) AS shows_amount
+                    FROM banner_stats_buckets
+                    GROUP BY slot_id, group_id, banner_id) b
+                   ON b.slot_id = bs.slot_id AND b.group_id = bs.group_id AND b.banner_id = bs.banner_id
+WHERE bs.clicks_amount > COALESCE(b.clicks_amount, 0)
+   OR bs.shows_amount > COALESCE(b.shows_amount, 0)
+ON CONFLICT ON CONSTRAINT banner_stats_buckets_pkey DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM banner_stats_buckets WHERE bucket_start = to_timestamp(0);
+-- +goose StatementEnd